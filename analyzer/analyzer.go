@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// Analyzer ...
+//
+// It searches several best moves in a position deepening the search
+// step by step and reports their lines after each step.
+type Analyzer struct {
+	writer      io.Writer
+	settings    game.SearchSettings
+	moveEncoder game.MoveEncoder
+	lineCount   int
+}
+
+// NewAnalyzer ...
+//
+// The settings limit the analysis by the deep and the duration.
+// The line count is a maximal count of reported moves.
+func NewAnalyzer(
+	writer io.Writer,
+	settings game.SearchSettings,
+	moveEncoder game.MoveEncoder,
+	lineCount int,
+) Analyzer {
+	return Analyzer{
+		writer:      writer,
+		settings:    settings,
+		moveEncoder: moveEncoder,
+		lineCount:   lineCount,
+	}
+}
+
+// Run ...
+//
+// It returns nil, when the analysis reaches the limits of the settings
+// or the context is done. Lines of an interrupted step aren't reported.
+// A checkmate or a draw in the position is returned as an error.
+func (analyzer Analyzer) Run(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) error {
+	terminator := terminators.NewGroupTerminator(
+		terminators.NewTimeTerminator(time.Now, analyzer.settings.Duration),
+		game.NewContextTerminator(ctx),
+	)
+	for deep := 1; deep <= analyzer.settings.Deep; deep++ {
+		lines, err := game.SearchLines(
+			analyzer.settings.Cache,
+			storage,
+			color,
+			deep,
+			terminator,
+			analyzer.lineCount,
+		)
+		if err != nil {
+			return err // don't wrap
+		}
+		// the terminator doesn't depend on the deep
+		if terminator.IsSearchTerminated(deep) {
+			return nil
+		}
+
+		if err := analyzer.writeLines(storage, color, deep, lines); err != nil {
+			return err // don't wrap
+		}
+	}
+
+	return nil
+}
+
+func (analyzer Analyzer) writeLines(
+	storage models.PieceStorage,
+	color models.Color,
+	deep int,
+	lines []game.ScoredLine,
+) error {
+	fmt.Fprintf(analyzer.writer, "deep %d:\n", deep) // nolint: errcheck
+	for index, line := range lines {
+		moveText, err := analyzer.moveEncoder(storage, line.Move)
+		if err != nil {
+			return fmt.Errorf("unable to encode the move: %s", err)
+		}
+
+		analysisText, err := game.EncodeMoveAnalysis(
+			storage,
+			color,
+			line.Move,
+			line.Analysis,
+			analyzer.moveEncoder,
+		)
+		if err != nil {
+			return err // don't wrap
+		}
+
+		fmt.Fprintf( // nolint: errcheck
+			analyzer.writer,
+			"%d. %s %s\n",
+			index+1,
+			moveText,
+			analysisText,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func newTestSearchSettings(deep int) game.SearchSettings {
+	cache := caches.NewParallelCache(caches.NewStringHashingCache(
+		1e6,
+		uci.EncodePieceStorage,
+	))
+	return game.SearchSettings{
+		Cache:    cache,
+		Deep:     deep,
+		Duration: time.Minute,
+	}
+}
+
+func TestAnalyzerRun(test *testing.T) {
+	type args struct {
+		fen      string
+		deep     int
+		isCancel bool
+	}
+	type data struct {
+		args      args
+		wantParts []string
+		wantErr   error
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				// white mates in one, e.g. by b1b5
+				fen:      "k4/2K2/5/5/1Q3",
+				deep:     2,
+				isCancel: false,
+			},
+			wantParts: []string{
+				"deep 1:\n1. ",
+				"deep 2:\n1. b1",
+				" (mate in 1)\n2. b1",
+				" (mate in 1)\n",
+			},
+			wantErr: nil,
+		},
+		{
+			args: args{
+				fen:      "k4/2K2/5/5/1Q3",
+				deep:     2,
+				isCancel: true,
+			},
+			wantParts: nil,
+			wantErr:   nil,
+		},
+		{
+			args: args{
+				// the white king is mated by the black queen
+				fen:      "Kq3/2k2/5/5/5",
+				deep:     2,
+				isCancel: false,
+			},
+			wantParts: nil,
+			wantErr:   minimax.ErrCheckmate,
+		},
+	} {
+		storage, err := uci.DecodePieceStorage(
+			data.args.fen,
+			pieces.NewPiece,
+			models.NewBoard,
+		)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if data.args.isCancel {
+			cancel()
+		}
+
+		var output bytes.Buffer
+		analyzer := NewAnalyzer(
+			&output,
+			newTestSearchSettings(data.args.deep),
+			game.EncodeUCIMove,
+			2,
+		)
+		gotErr := analyzer.Run(ctx, storage, models.White)
+		cancel()
+
+		got := output.String()
+		if len(data.wantParts) == 0 && got != "" {
+			test.Fail()
+		}
+		for _, part := range data.wantParts {
+			if !strings.Contains(got, part) {
+				test.Fail()
+			}
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestAnalyzerRun_withLimitedLines(test *testing.T) {
+	storage, err := uci.DecodePieceStorage(
+		"k4/2K2/5/5/1Q3",
+		pieces.NewPiece,
+		models.NewBoard,
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	analyzer := NewAnalyzer(
+		&output,
+		newTestSearchSettings(1),
+		game.EncodeUCIMove,
+		1,
+	)
+	if err := analyzer.Run(context.Background(), storage, models.White); err != nil {
+		test.Fatal(err)
+	}
+
+	if !strings.HasPrefix(output.String(), "deep 1:\n1. ") ||
+		strings.Contains(output.String(), "\n2. ") {
+		test.Fail()
+	}
+}
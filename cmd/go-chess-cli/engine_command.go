@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// the engine is started in its own process group, so Ctrl-C isn't sent to it
+// by the terminal; its search is stopped by the "stop" command instead
+func makeEngineCommand(path string) *exec.Cmd {
+	command := exec.Command(path) // nolint: gosec
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return command
+}
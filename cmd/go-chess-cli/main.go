@@ -2,25 +2,31 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"math/rand"
 	"os"
-	"runtime"
+	"os/signal"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/thewizardplusplus/go-chess-cli/analyzer"
 	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
 	"github.com/thewizardplusplus/go-chess-cli/encoding/unicode"
+	"github.com/thewizardplusplus/go-chess-cli/game"
 	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	"github.com/thewizardplusplus/go-chess-cli/protocols"
+	"github.com/thewizardplusplus/go-chess-cli/viewer"
 	minimax "github.com/thewizardplusplus/go-chess-minimax"
 	"github.com/thewizardplusplus/go-chess-minimax/caches"
-	"github.com/thewizardplusplus/go-chess-minimax/evaluators"
-	moves "github.com/thewizardplusplus/go-chess-minimax/models"
-	"github.com/thewizardplusplus/go-chess-minimax/terminators"
 	models "github.com/thewizardplusplus/go-chess-models"
 	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
 	"github.com/thewizardplusplus/go-chess-models/pieces"
@@ -62,148 +68,310 @@ var (
 	}
 )
 
+const (
+	reverseVideoMode   = 7
+	noReverseVideoMode = 27
+)
+
+// flags of a session are saved in PGN tags with this prefix
+// (e.g. the -deep flag is saved in the FlagDeep tag)
+const sessionFlagPrefix = "Flag"
+
+// they aren't saved in a session, because they don't describe a game
+// or are replaced by its PGN; also, they choose a mode of the program
+// before a session is restored
+// nolint: gochecknoglobals
+var unsavedFlags = map[string]struct{}{
+	"fen":          {},
+	"pgn":          {},
+	"ply":          {},
+	"resume":       {},
+	"analyze":      {},
+	"analyzeColor": {},
+	"protocol":     {},
+	"autosave":     {},
+}
+
 type colorCodeGroup map[models.Color]int
 
+type searchFlags struct {
+	deep      int
+	duration  time.Duration
+	cacheSize int
+}
+
+func (flags searchFlags) withDefaults(defaults searchFlags) searchFlags {
+	if flags.deep == 0 {
+		flags.deep = defaults.deep
+	}
+	if flags.duration == 0 {
+		flags.duration = defaults.duration
+	}
+	if flags.cacheSize == 0 {
+		flags.cacheSize = defaults.cacheSize
+	}
+
+	return flags
+}
+
 func setTTYMode(mode int) string {
 	return fmt.Sprintf("\x1b[%dm", mode)
 }
 
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func markSquare(text string) string {
+	return setTTYMode(reverseVideoMode) + text + setTTYMode(noReverseVideoMode)
+}
+
 func makeColorizer(colorsCodes colorCodeGroup) ascii.Colorizer {
 	return func(text string, color models.Color) string {
 		return setTTYMode(colorsCodes[color]) + text + setTTYMode(0)
 	}
 }
 
-func search(
-	cache caches.Cache,
-	storage models.PieceStorage,
-	color models.Color,
-	terminator terminators.SearchTerminator,
-) (moves.ScoredMove, error) {
-	searcher := minimax.NewParallelSearcher(
-		terminator,
-		runtime.NumCPU(),
-		func() minimax.MoveSearcher {
-			innerSearcher := minimax.NewAlphaBetaSearcher(
-				models.MoveGenerator{},
-				nil, // terminator will be set automatically by the iterative searcher
-				evaluators.MaterialEvaluator{},
-			)
-
-			if cache != nil {
-				// make and bind a cached searcher to inner one
-				minimax.NewCachedSearcher(innerSearcher, cache)
-			}
-
-			return minimax.NewIterativeSearcher(
-				innerSearcher,
-				nil, // terminator will be set automatically by the parallel searcher
-			)
-		},
-	)
+func decodeHumanColor(text string) (climodels.OptionalColor, error) {
+	switch text {
+	case "random":
+		var color models.Color
+		if rand.Intn(2) == 0 {
+			color = models.Black
+		} else {
+			color = models.White
+		}
 
-	return searcher.SearchMove(
-		storage,
-		color,
-		0, // initial deep
-		moves.NewBounds(),
-	)
+		return climodels.NewOptionalColor(color), nil
+	case "none", "both":
+		return climodels.WithoutColor, nil
+	}
+
+	color, err := ascii.DecodeColor(text)
+	if err != nil {
+		return climodels.OptionalColor{}, err // don't wrap
+	}
+
+	return climodels.NewOptionalColor(color), nil
 }
 
-func check(storage models.PieceStorage, color models.Color) error {
-	// minimal deep, at which a game state will be detected
-	terminator := terminators.NewDeepTerminator(1)
-	_, err := search(
-		nil, // without a cache
-		storage,
-		color,
-		terminator,
-	)
-	return err // don't wrap
+func decodeEngineColor(text string) (climodels.OptionalColor, error) {
+	if text == "both" {
+		return climodels.WithoutColor, nil
+	}
+
+	color, err := ascii.DecodeColor(text)
+	if err != nil {
+		return climodels.OptionalColor{}, err // don't wrap
+	}
+
+	return climodels.NewOptionalColor(color), nil
+}
+
+func decodeNotation(text string) (game.MoveEncoder, error) {
+	switch text {
+	case "uci":
+		return game.EncodeUCIMove, nil
+	case "san":
+		return san.EncodeMove, nil
+	}
+
+	return nil, errors.New("unknown notation")
 }
 
-func writePrompt(
+func decodeIncrementMode(text string) (game.IncrementMode, error) {
+	switch text {
+	case "fischer":
+		return game.FischerIncrement, nil
+	case "bronstein":
+		return game.BronsteinIncrement, nil
+	}
+
+	return 0, errors.New("unknown increment mode")
+}
+
+func makeSearchSettings(flags searchFlags) game.SearchSettings {
+	cache := caches.NewParallelCache(caches.NewStringHashingCache(
+		flags.cacheSize,
+		uci.EncodePieceStorage,
+	))
+	return game.SearchSettings{
+		Cache:    cache,
+		Deep:     flags.deep,
+		Duration: flags.duration,
+	}
+}
+
+func runViewer(
+	path string,
+	ply int,
 	storageEncoder ascii.PieceStorageEncoder,
-	storage models.PieceStorage,
-	color models.Color,
-	side climodels.Side,
 ) error {
-	text := storageEncoder.EncodePieceStorage(storage)
-	fmt.Println(text)
-
-	if err := check(storage, color); err != nil {
-		return err // don't wrap
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read the games: %s", err)
 	}
 
-	var mark string
-	if side == climodels.Searcher {
-		mark = "(searching) "
+	games, err := pgn.DecodeGames(string(text))
+	if err != nil {
+		return fmt.Errorf("unable to decode the games: %s", err)
 	}
 
-	text = ascii.EncodeColor(color)
-	fmt.Printf("%s> %s", text, mark) // don't break the line
+	gameViewer := viewer.NewViewer(
+		bufio.NewReader(os.Stdin),
+		os.Stdout,
+		storageEncoder.WithTopColor(models.Black),
+		games,
+	)
+	if len(games) != 0 {
+		if err := gameViewer.Jump(ply); err != nil {
+			return err // don't wrap
+		}
+	}
 
-	return nil
+	return gameViewer.Run()
 }
 
-func readMove(
-	reader *bufio.Reader,
-	storageEncoder ascii.PieceStorageEncoder,
+func runAnalyzer(
 	storage models.PieceStorage,
 	color models.Color,
-	side climodels.Side,
-) (models.Move, error) {
-	if err := writePrompt(storageEncoder, storage, color, side); err != nil {
-		return models.Move{}, err // don't wrap
-	}
+	settings game.SearchSettings,
+	moveEncoder game.MoveEncoder,
+	lineCount int,
+	storageEncoder ascii.PieceStorageEncoder,
+) error {
+	fmt.Println(storageEncoder.EncodePieceStorage(storage))
+	fmt.Println("press Enter to stop the analysis")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		// the end of the input doesn't stop the analysis,
+		// so it can be run with the redirected input
+		if _, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
+			cancel()
+		}
+	}()
+
+	analysisAnalyzer := analyzer.NewAnalyzer(
+		os.Stdout,
+		settings,
+		moveEncoder,
+		lineCount,
+	)
+	return analysisAnalyzer.Run(ctx, storage, color)
+}
 
-	text, err := reader.ReadString('\n')
+func offerToSave(human game.HumanPlayer, currentGame *game.Game) error {
+	path, err := human.Ask(
+		context.Background(),
+		"save the game in PGN to the file (empty to skip)> ",
+	)
 	if err != nil && err != io.EOF {
-		return models.Move{}, fmt.Errorf("unable to read the move: %s", err)
+		return err // don't wrap
+	}
+	if path == "" {
+		return nil
 	}
 
-	text = strings.TrimSuffix(text, "\n")
-	move, err := uci.DecodeMove(text)
+	return currentGame.SavePGN(path, game.ErrInterrupted)
+}
+
+func makeAutosavePath() (string, error) {
+	cacheDirectory, err := os.UserCacheDir()
 	if err != nil {
-		return models.Move{}, fmt.Errorf("unable to decode the move: %s", err)
+		return "", fmt.Errorf("unable to find the cache directory: %s", err)
 	}
 
-	if err = storage.CheckMove(move); err != nil {
-		return models.Move{}, fmt.Errorf("incorrect move: %s", err)
+	directory := filepath.Join(cacheDirectory, "go-chess-cli")
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", fmt.Errorf("unable to create the cache directory: %s", err)
 	}
 
-	if piece, _ := storage.Piece(move.Start); piece.Color() != color {
-		return models.Move{}, errors.New("incorrect move: opponent piece")
-	}
+	return filepath.Join(directory, "autosave.pgn"), nil
+}
+
+func offerToContinue(reader *bufio.Reader, writer io.Writer) (bool, error) {
+	fmt.Fprint( // nolint: errcheck
+		writer,
+		"an interrupted game is found, continue it? (y/n)> ",
+	)
 
-	nextStorage := storage.ApplyMove(move)
-	nextColor := color.Negative()
-	if err = check(nextStorage, nextColor); err == models.ErrKingCapture {
-		return models.Move{}, errors.New("incorrect move: check")
+	answer, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("unable to read the answer: %s", err)
 	}
 
-	return move, nil
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return strings.HasPrefix(answer, "y"), nil
 }
 
-func searchMove(
-	cache caches.Cache,
-	storageEncoder ascii.PieceStorageEncoder,
-	storage models.PieceStorage,
-	color models.Color,
-	side climodels.Side,
-	deep int,
-	duration time.Duration,
-) (models.Move, error) {
-	if err := writePrompt(storageEncoder, storage, color, side); err != nil {
-		return models.Move{}, err // don't wrap
+func encodeSessionFlags() map[string]string {
+	tags := make(map[string]string)
+	flag.VisitAll(func(item *flag.Flag) {
+		if _, ok := unsavedFlags[item.Name]; ok {
+			return
+		}
+
+		name := strings.ToUpper(item.Name[:1]) + item.Name[1:]
+		tags[sessionFlagPrefix+name] = item.Value.String()
+	})
+
+	return tags
+}
+
+// flags set explicitly in the command line have a priority
+// over saved ones; flags, which can't be restored, are skipped
+// with a warning
+func restoreSessionFlags(tags map[string]string) {
+	explicitFlags := make(map[string]struct{})
+	flag.Visit(func(item *flag.Flag) {
+		explicitFlags[item.Name] = struct{}{}
+	})
+
+	for tag, value := range tags {
+		name := strings.TrimPrefix(tag, sessionFlagPrefix)
+		if name == tag || name == "" {
+			continue
+		}
+
+		name = strings.ToLower(name[:1]) + name[1:]
+		if _, ok := unsavedFlags[name]; ok {
+			continue
+		}
+		if _, ok := explicitFlags[name]; ok {
+			continue
+		}
+
+		// a session can be saved by another version of the program
+		if flag.Lookup(name) == nil {
+			log.Printf("warning: unable to restore the -%s flag: unknown flag", name)
+			continue
+		}
+		if err := flag.Set(name, value); err != nil {
+			log.Printf("warning: unable to restore the -%s flag: %s", name, err)
+		}
 	}
+}
 
-	terminator := terminators.NewGroupTerminator(
-		terminators.NewDeepTerminator(deep),
-		terminators.NewTimeTerminator(time.Now, duration),
-	)
-	move, _ := search(cache, storage, color, terminator) // nolint: gosec
-	return move.Move, nil
+func loadSession(path string) (pgn.Game, error) {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return pgn.Game{}, fmt.Errorf("unable to read the session: %s", err)
+	}
+
+	games, err := pgn.DecodeGames(string(text))
+	if err != nil {
+		return pgn.Game{}, fmt.Errorf("unable to decode the session: %s", err)
+	}
+	if len(games) == 0 {
+		return pgn.Game{}, errors.New("unable to decode the session: no games")
+	}
+
+	// the last game is the most recent one
+	return games[len(games)-1], nil
 }
 
 func main() {
@@ -217,7 +385,7 @@ func main() {
 	humanColor := flag.String(
 		"humanColor",
 		"random",
-		"human color (allowed: random, black, white)",
+		"human color (allowed: random, black, white, none, both)",
 	)
 	deep := flag.Int("deep", 5, "search deep")
 	duration := flag.Duration(
@@ -226,6 +394,28 @@ func main() {
 		"search duration (e.g. 72h3m0.5s)",
 	)
 	cacheSize := flag.Int("cacheSize", 1e6, "maximal cache size (in items)")
+	whiteDeep := flag.Int("whiteDeep", 0, "search deep for white (default: -deep)")
+	whiteDuration := flag.Duration(
+		"whiteDuration",
+		0,
+		"search duration for white (default: -duration)",
+	)
+	whiteCacheSize := flag.Int(
+		"whiteCacheSize",
+		0,
+		"maximal cache size for white (default: -cacheSize)",
+	)
+	blackDeep := flag.Int("blackDeep", 0, "search deep for black (default: -deep)")
+	blackDuration := flag.Duration(
+		"blackDuration",
+		0,
+		"search duration for black (default: -duration)",
+	)
+	blackCacheSize := flag.Int(
+		"blackCacheSize",
+		0,
+		"maximal cache size for black (default: -cacheSize)",
+	)
 	useUnicode := flag.Bool("unicode", true, "use Unicode to display pieces")
 	colorfulPieces := flag.Bool(
 		"colorfulPieces",
@@ -260,25 +450,206 @@ func main() {
 			"for setting a color of white squares",
 	)
 	wide := flag.Bool("wide", true, "display the board wide")
+	pgnOut := flag.String("pgnOut", "", "file to save the game in PGN")
+	flipBoard := flag.Bool(
+		"flipBoard",
+		false,
+		"turn the board toward a color to move",
+	)
+	moveList := flag.Bool(
+		"moveList",
+		false,
+		"display a numbered list of moves to the right of the board",
+	)
+	timeBase := flag.Duration(
+		"timeBase",
+		0,
+		"base time of the chess clock for each color (default: without a clock)",
+	)
+	timeMovesPerPeriod := flag.Int(
+		"timeMovesPerPeriod",
+		0,
+		"count of moves, after which the base time is added again "+
+			"(default: the whole game)",
+	)
+	timeIncrement := flag.Duration(
+		"timeIncrement",
+		0,
+		"time increment after each move",
+	)
+	timeIncrementMode := flag.String(
+		"timeIncrementMode",
+		"fischer",
+		"mode of the time increment (allowed: fischer, bronstein)",
+	)
+	notation := flag.String(
+		"notation",
+		"uci",
+		"notation to display moves (allowed: uci, san)",
+	)
+	pgnIn := flag.String("pgn", "", "file in PGN to view instead of playing")
+	ply := flag.Int("ply", 0, "ply of the first viewed game to start from")
+	searchInfo := flag.Bool(
+		"searchInfo",
+		true,
+		"display a search state while the computer thinks",
+	)
+	enginePath := flag.String(
+		"engine",
+		"",
+		"external UCI engine to play instead of the built-in searcher",
+	)
+	engineColor := flag.String(
+		"engineColor",
+		"both",
+		"color of the external engine (allowed: black, white, both)",
+	)
+	hintDuration := flag.Duration(
+		"hintDuration",
+		time.Second,
+		"search duration for hints",
+	)
+	autoHint := flag.Bool(
+		"autoHint",
+		false,
+		"suggest a move before each move of a human",
+	)
+	contempt := flag.Float64(
+		"contempt",
+		0,
+		"score (in pawns), by which the computer underestimates a draw "+
+			"when it answers a draw offer",
+	)
+	analyze := flag.Int(
+		"analyze",
+		0,
+		"count of best moves to analyze instead of playing (default: play)",
+	)
+	analyzeColor := flag.String(
+		"analyzeColor",
+		"white",
+		"color to move in the analyzed position (allowed: black, white)",
+	)
+	protocol := flag.String(
+		"protocol",
+		"cli",
+		"protocol to communicate (allowed: cli, uci, xboard; "+
+			"in uci and xboard, the -fen board is used by the startpos "+
+			"and new commands instead of the standard initial position)",
+	)
+	resume := flag.String(
+		"resume",
+		"",
+		"file in PGN saved by the game to continue it with its settings",
+	)
+	autosave := flag.Bool(
+		"autosave",
+		true,
+		"save the game after each move to continue it after a crash",
+	)
 	flag.Parse()
 
+	// stdin is shared by all questions, so they don't lose buffered input
+	stdin := bufio.NewReader(os.Stdin)
+	resumePath := *resume
+	var autosavePath string
+	if *autosave && *protocol == "cli" && *pgnIn == "" && *analyze == 0 {
+		path, err := makeAutosavePath()
+		if err != nil {
+			log.Print("error: ", err)
+		}
+
+		autosavePath = path
+	}
+	if _, err := os.Stat(autosavePath); err == nil && resumePath == "" {
+		ok, err := offerToContinue(stdin, os.Stdout)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+
+		if ok {
+			resumePath = autosavePath
+		} else if err := os.Remove(autosavePath); err != nil {
+			log.Print("error: ", err)
+		}
+	}
+
+	var savedGame *pgn.Game
+	if resumePath != "" {
+		loadedGame, err := loadSession(resumePath)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		restoreSessionFlags(loadedGame.Tags)
+
+		savedGame = &loadedGame
+	}
+
 	storage, err := uci.DecodePieceStorage(*fen, pieces.NewPiece, models.NewBoard)
 	if err != nil {
 		log.Fatal("unable to decode the board: ", err)
 	}
+	if savedGame != nil {
+		storage = savedGame.InitialStorage
+	}
 
-	parsedHumanColor, err := ascii.DecodeColor(*humanColor)
-	switch {
-	case err == nil:
-	case *humanColor == "random":
-		if rand.Intn(2) == 0 {
-			parsedHumanColor = models.Black
-		} else {
-			parsedHumanColor = models.White
+	commonSearchFlags := searchFlags{*deep, *duration, *cacheSize}
+	switch *protocol {
+	case "cli":
+	case "uci":
+		engine := protocols.NewUCIEngine(
+			os.Stdin,
+			os.Stdout,
+			storage,
+			makeSearchSettings(commonSearchFlags),
+		)
+		if err := engine.Run(); err != nil {
+			log.Fatal("error: ", err)
+		}
+
+		return
+	case "xboard":
+		engine := protocols.NewXBoardEngine(
+			os.Stdin,
+			os.Stdout,
+			storage,
+			makeSearchSettings(commonSearchFlags),
+		)
+		if err := engine.Run(); err != nil {
+			log.Fatal("error: ", err)
 		}
+
+		return
 	default:
+		log.Fatal("unable to decode the protocol: unknown protocol")
+	}
+
+	moveEncoder, err := decodeNotation(*notation)
+	if err != nil {
+		log.Fatal("unable to decode the notation: ", err)
+	}
+
+	incrementMode, err := decodeIncrementMode(*timeIncrementMode)
+	if err != nil {
+		log.Fatal("unable to decode the increment mode: ", err)
+	}
+
+	parsedHumanColor, err := decodeHumanColor(*humanColor)
+	if err != nil {
 		log.Fatal("unable to decode the color: ", err)
 	}
+	if *humanColor == "random" {
+		// a resumed session should keep the chosen color
+		flag.Set( // nolint: errcheck, gosec
+			"humanColor",
+			ascii.EncodeColor(parsedHumanColor.Value),
+		)
+	}
+
+	parsedEngineColor, err := decodeEngineColor(*engineColor)
+	if err != nil {
+		log.Fatal("unable to decode the engine color: ", err)
+	}
 
 	var pieceEncoder ascii.PieceEncoder
 	var placeholder string
@@ -326,53 +697,180 @@ func main() {
 		squareColorizer = ascii.WithoutColor
 	}
 
-	side := climodels.NewSide(parsedHumanColor)
-	reader := bufio.NewReader(os.Stdin)
+	topColor := models.Black
+	if parsedHumanColor.IsSet {
+		topColor = parsedHumanColor.Value.Negative()
+	}
+
 	storageEncoder := ascii.NewPieceStorageEncoder(
 		pieceEncoder,
 		placeholder,
 		margins,
 		squareColorizer,
-		parsedHumanColor.Negative(),
+		topColor,
 		1,
 	)
-	cache := caches.NewParallelCache(caches.NewStringHashingCache(
-		*cacheSize,
-		uci.EncodePieceStorage,
-	))
-loop:
-	for {
-		var move models.Move
-		var err error
-		switch side {
-		case climodels.Human:
-			move, err = readMove(reader, storageEncoder, storage, parsedHumanColor, side)
-		case climodels.Searcher:
-			move, err = searchMove(
-				cache,
-				storageEncoder,
-				storage,
-				parsedHumanColor.Negative(),
-				side,
-				*deep,
-				*duration,
-			)
-			if err == nil {
-				text := uci.EncodeMove(move)
-				fmt.Println(text)
-			}
+	if *pgnIn != "" {
+		if err := runViewer(*pgnIn, *ply, storageEncoder); err != nil {
+			log.Fatal("error: ", err)
+		}
+
+		return
+	}
+	if *analyze > 0 {
+		color, err := ascii.DecodeColor(*analyzeColor)
+		if err != nil {
+			log.Fatal("unable to decode the analyzed color: ", err)
 		}
+
+		err = runAnalyzer(
+			storage,
+			color,
+			makeSearchSettings(commonSearchFlags),
+			moveEncoder,
+			*analyze,
+			storageEncoder,
+		)
 		switch err {
 		case nil:
 		case minimax.ErrCheckmate, minimax.ErrDraw:
-			log.Print("game in the state: ", err)
-			break loop
+			log.Print("game over: ", game.Termination(err, color))
 		default:
+			log.Fatal("error: ", err)
+		}
+
+		return
+	}
+
+	colorSearchFlags := map[models.Color]searchFlags{
+		models.White: {*whiteDeep, *whiteDuration, *whiteCacheSize},
+		models.Black: {*blackDeep, *blackDuration, *blackCacheSize},
+	}
+	searchers := make(game.Players)
+	searchSettings := make(map[models.Color]game.SearchSettings)
+	var engines []*game.EnginePlayer
+	for color, flags := range colorSearchFlags {
+		settings := makeSearchSettings(flags.withDefaults(commonSearchFlags))
+		settings.Contempt = *contempt
+		searchSettings[color] = settings
+
+		isEngineColor := !parsedEngineColor.IsSet ||
+			parsedEngineColor.Value == color
+		isHumanColor := parsedHumanColor.IsSet && parsedHumanColor.Value == color
+		if *enginePath == "" || !isEngineColor || isHumanColor {
+			searchers[color] = game.NewSearcherPlayer(settings)
+			continue
+		}
+
+		engine, err := game.StartEnginePlayer(
+			context.Background(),
+			makeEngineCommand(*enginePath),
+			settings,
+		)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+
+		searchers[color] = engine
+		engines = append(engines, engine)
+	}
+
+	var players game.Players
+	human := game.NewHumanPlayer(stdin, os.Stdout)
+	switch {
+	case parsedHumanColor.IsSet:
+		players = game.NewPlayers(
+			human,
+			searchers[parsedHumanColor.Value.Negative()],
+			parsedHumanColor.Value,
+		)
+	case *humanColor == "both":
+		players = game.Players{
+			models.White: human,
+			models.Black: human,
+		}
+	default:
+		players = searchers
+	}
+
+	currentGame := game.NewGame(os.Stdout, storageEncoder, players, storage)
+	currentGame.SetBoardFlipping(*flipBoard)
+	currentGame.SetMoveEncoder(moveEncoder)
+	currentGame.SetMoveList(*moveList)
+	if *searchInfo {
+		if isTerminal(os.Stdout) {
+			currentGame.SetSearchInfoMode(game.InPlaceSearchInfo)
+		} else {
+			currentGame.SetSearchInfoMode(game.PlainSearchInfo)
+		}
+	}
+	if *timeBase != 0 {
+		currentGame.SetClock(game.NewClock(game.TimeControl{
+			Base:           *timeBase,
+			MovesPerPeriod: *timeMovesPerPeriod,
+			Increment:      *timeIncrement,
+			IncrementMode:  incrementMode,
+		}, time.Now))
+	}
+	currentGame.SetPGNPath(*pgnOut)
+	currentGame.SetAutosavePath(autosavePath)
+
+	// hints share the cache with the opponent of a human,
+	// so they warm it for its next search
+	hintColor := models.White
+	if parsedHumanColor.IsSet {
+		hintColor = parsedHumanColor.Value.Negative()
+	}
+	currentGame.SetHintSettings(game.SearchSettings{
+		Cache:    searchSettings[hintColor].Cache,
+		Deep:     searchSettings[hintColor].Deep,
+		Duration: *hintDuration,
+	})
+	if *colorfulPieces || *colorfulBoard {
+		currentGame.SetHintMarker(markSquare)
+	}
+	currentGame.SetAutoHint(*autoHint)
+	currentGame.SetSessionTags(encodeSessionFlags())
+	if savedGame != nil {
+		if err := currentGame.Resume(*savedGame); err != nil {
+			log.Fatal("error: ", err)
+		}
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	currentGame.SetInterrupts(interrupts)
+
+	err = currentGame.Play(context.Background())
+	// a next interrupt kills the program as usual
+	signal.Stop(interrupts)
+	for _, engine := range engines {
+		if err := engine.Close(); err != nil {
+			log.Print("error: ", err)
+		}
+	}
+	if *pgnOut != "" {
+		if err := currentGame.SavePGN(*pgnOut, err); err != nil {
 			log.Print("error: ", err)
-			continue loop
 		}
+	} else if err == game.ErrInterrupted {
+		if err := offerToSave(human, currentGame); err != nil {
+			log.Print("error: ", err)
+		}
+	}
 
-		storage = storage.ApplyMove(move)
-		side = side.Invert()
+	switch {
+	case game.IsWin(err), game.IsDraw(err):
+		log.Print("game over: ", currentGame.Termination(err))
+	case err == game.ErrQuit, err == game.ErrInterrupted, err == io.EOF:
+	default:
+		log.Fatal("error: ", err)
+	}
+
+	// the game isn't interrupted by a crash, so it shouldn't be continued
+	if autosavePath != "" {
+		if err := os.Remove(autosavePath); err != nil && !os.IsNotExist(err) {
+			log.Print("error: ", err)
+		}
 	}
 }
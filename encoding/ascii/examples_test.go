@@ -87,6 +87,49 @@ func ExamplePieceStorageEncoder_EncodePieceStorage_withMargins() {
 	//    a b c d e f g h
 }
 
+func ExamplePieceStorageEncoder_EncodePieceStorage_withPanel() {
+	margins := ascii.Margins{
+		Piece: ascii.PieceMargins{
+			HorizontalMargins: ascii.HorizontalMargins{
+				Left: 1,
+			},
+		},
+		Legend: ascii.LegendMargins{
+			Rank: ascii.HorizontalMargins{
+				Right: 1,
+			},
+		},
+	}
+
+	var panel []string
+	for moveNumber := 1; moveNumber <= 9; moveNumber++ {
+		panel = append(panel, fmt.Sprintf("%d. move", moveNumber))
+	}
+
+	const fen = "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R"
+	storage, _ := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	encoder := ascii.NewPieceStorageEncoder(
+		uci.EncodePiece,
+		"x",
+		margins,
+		ascii.WithoutColor,
+		models.Black,
+		1,
+	)
+	fmt.Printf("%v\n", encoder.WithPanel(panel...).EncodePieceStorage(storage))
+
+	// Output:
+	// 8  r x x x k x x r  2. move
+	// 7  p x p p q p b x  3. move
+	// 6  b n x x p n p x  4. move
+	// 5  x x x P N x x x  5. move
+	// 4  x p x x P x x x  6. move
+	// 3  x x N x x Q x p  7. move
+	// 2  P P P B B P P P  8. move
+	// 1  R x x x K x x R  9. move
+	//    a b c d e f g h
+}
+
 func ExamplePieceStorageEncoder_EncodePieceStorage_withColors() {
 	colorizer := func(text string, color climodels.OptionalColor) string {
 		var colorMark byte
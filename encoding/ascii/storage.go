@@ -11,6 +11,11 @@ import (
 // PieceEncoder ...
 type PieceEncoder func(piece models.Piece) string
 
+// Marker ...
+//
+// It highlights a text of a square.
+type Marker func(text string) string
+
 // PieceStorageEncoder ...
 type PieceStorageEncoder struct {
 	encoder     PieceEncoder
@@ -19,6 +24,9 @@ type PieceStorageEncoder struct {
 	colorizer   OptionalColorizer
 	topColor    models.Color
 	pieceWidth  int
+	marker      Marker
+	marks       []models.Position
+	panel       []string
 }
 
 // NewPieceStorageEncoder ...
@@ -40,6 +48,45 @@ func NewPieceStorageEncoder(
 	}
 }
 
+// TopColor ...
+func (encoder PieceStorageEncoder) TopColor() models.Color {
+	return encoder.topColor
+}
+
+// WithTopColor ...
+//
+// It returns a copy of the encoder with the replaced top color.
+func (encoder PieceStorageEncoder) WithTopColor(
+	topColor models.Color,
+) PieceStorageEncoder {
+	encoder.topColor = topColor
+	return encoder
+}
+
+// WithMarks ...
+//
+// It returns a copy of the encoder, which highlights the positions
+// by the marker.
+func (encoder PieceStorageEncoder) WithMarks(
+	marker Marker,
+	positions ...models.Position,
+) PieceStorageEncoder {
+	encoder.marker = marker
+	encoder.marks = append([]models.Position(nil), positions...)
+	return encoder
+}
+
+// WithPanel ...
+//
+// It returns a copy of the encoder, which places the lines to the right
+// of ranks. Only the last lines, which fit the ranks height, are kept.
+func (encoder PieceStorageEncoder) WithPanel(
+	lines ...string,
+) PieceStorageEncoder {
+	encoder.panel = append([]string(nil), lines...)
+	return encoder
+}
+
 // EncodePieceStorage ...
 func (encoder PieceStorageEncoder) EncodePieceStorage(
 	storage models.PieceStorage,
@@ -67,6 +114,9 @@ func (encoder PieceStorageEncoder) EncodePieceStorage(
 		} else {
 			encodedPiece = encoder.placeholder
 		}
+		if encoder.isMarked(position) {
+			encodedPiece = encoder.marker(encodedPiece)
+		}
 		currentRank += encoder.wrapWithSpaces(
 			encodedPiece,
 			pieceMargins.HorizontalMargins,
@@ -97,6 +147,8 @@ func (encoder PieceStorageEncoder) EncodePieceStorage(
 		)...)
 	}
 
+	sparseRanks = encoder.addPanel(sparseRanks, len(ranks))
+
 	legendRank :=
 		encoder.spaces(legendMargins.Rank.Width(1), climodels.WithoutColor)
 	for i := 0; i < storage.Size().Width; i++ {
@@ -123,6 +175,40 @@ func (encoder PieceStorageEncoder) EncodePieceStorage(
 	return strings.Join(sparseRanks, "\n")
 }
 
+func (encoder PieceStorageEncoder) isMarked(position models.Position) bool {
+	for _, mark := range encoder.marks {
+		if mark == position {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the panel is separated from the board by a gap as wide as the rank legend,
+// so the board is framed symmetrically; panel lines are placed next to ranks
+// only, not next to their margins
+func (encoder PieceStorageEncoder) addPanel(
+	lines []string,
+	rankCount int,
+) []string {
+	panel := encoder.panel
+	if len(panel) > rankCount {
+		panel = panel[len(panel)-rankCount:]
+	}
+
+	pieceMargins := encoder.margins.Piece
+	legendMargins := encoder.margins.Legend
+	rankHeight := pieceMargins.Top + 1 + pieceMargins.Bottom
+	gap := encoder.spaces(legendMargins.Rank.Width(1), climodels.WithoutColor)
+	for index, line := range panel {
+		lineIndex := index*rankHeight + pieceMargins.Top
+		lines[lineIndex] += gap + line
+	}
+
+	return lines
+}
+
 func (encoder PieceStorageEncoder) wrapWithSpaces(
 	text string,
 	margins HorizontalMargins,
@@ -80,6 +80,96 @@ func TestNewPieceStorageEncoder(test *testing.T) {
 	}
 }
 
+func TestPieceStorageEncoderTopColor(test *testing.T) {
+	encoder := PieceStorageEncoder{topColor: models.White}
+	got := encoder.TopColor()
+
+	if got != models.White {
+		test.Fail()
+	}
+}
+
+func TestPieceStorageEncoderWithTopColor(test *testing.T) {
+	encoder := PieceStorageEncoder{
+		encoder:     uci.EncodePiece,
+		placeholder: "x",
+		margins:     Margins{},
+		colorizer:   WithoutColor,
+		topColor:    models.Black,
+		pieceWidth:  1,
+	}
+	got := encoder.WithTopColor(models.White)
+
+	if got.topColor != models.White {
+		test.Fail()
+	}
+	if got.placeholder != "x" {
+		test.Fail()
+	}
+	if got.pieceWidth != 1 {
+		test.Fail()
+	}
+	if encoder.topColor != models.Black {
+		test.Fail()
+	}
+}
+
+func TestPieceStorageEncoderWithMarks(test *testing.T) {
+	encoder := PieceStorageEncoder{
+		encoder:     uci.EncodePiece,
+		placeholder: "x",
+		margins:     Margins{},
+		colorizer:   WithoutColor,
+		topColor:    models.Black,
+		pieceWidth:  1,
+	}
+	positions := []models.Position{{File: 4, Rank: 0}, {File: 4, Rank: 1}}
+	got := encoder.WithMarks(strings.ToUpper, positions...)
+	positions[0] = models.Position{}
+
+	gotMarker := reflect.ValueOf(got.marker).Pointer()
+	wantMarker := reflect.ValueOf(strings.ToUpper).Pointer()
+	if gotMarker != wantMarker {
+		test.Fail()
+	}
+
+	wantMarks := []models.Position{{File: 4, Rank: 0}, {File: 4, Rank: 1}}
+	if !reflect.DeepEqual(got.marks, wantMarks) {
+		test.Fail()
+	}
+	if got.placeholder != "x" {
+		test.Fail()
+	}
+	if encoder.marks != nil {
+		test.Fail()
+	}
+}
+
+func TestPieceStorageEncoderWithPanel(test *testing.T) {
+	encoder := PieceStorageEncoder{
+		encoder:     uci.EncodePiece,
+		placeholder: "x",
+		margins:     Margins{},
+		colorizer:   WithoutColor,
+		topColor:    models.Black,
+		pieceWidth:  1,
+	}
+	lines := []string{"1. e2e4 e7e5", "2. g1f3"}
+	got := encoder.WithPanel(lines...)
+	lines[0] = ""
+
+	wantPanel := []string{"1. e2e4 e7e5", "2. g1f3"}
+	if !reflect.DeepEqual(got.panel, wantPanel) {
+		test.Fail()
+	}
+	if got.placeholder != "x" {
+		test.Fail()
+	}
+	if encoder.panel != nil {
+		test.Fail()
+	}
+}
+
 func TestPieceStorageEncoderEncodePieceStorage(test *testing.T) {
 	type fields struct {
 		encoder     PieceEncoder
@@ -88,6 +178,7 @@ func TestPieceStorageEncoderEncodePieceStorage(test *testing.T) {
 		colorizer   OptionalColorizer
 		topColor    models.Color
 		pieceWidth  int
+		panel       []string
 	}
 	type args struct {
 		boardInFEN string
@@ -687,6 +778,74 @@ func TestPieceStorageEncoderEncodePieceStorage(test *testing.T) {
 				"(n )(n )(n )(n )(n )(n )(n )(n )(n )\n" +
 				"(n )(n )(n )(n )(n )(n )(n )(n )(n )",
 		},
+		{
+			fields: fields{
+				encoder:     uci.EncodePiece,
+				placeholder: "x",
+				margins: Margins{
+					Piece: PieceMargins{
+						VerticalMargins: VerticalMargins{
+							Top:    1,
+							Bottom: 2,
+						},
+					},
+					Board: VerticalMargins{
+						Top: 1,
+					},
+				},
+				colorizer:  WithoutColor,
+				topColor:   models.Black,
+				pieceWidth: 1,
+				panel: []string{
+					"1. e2e4 e7e5",
+					"2. g1f3 b8c6",
+					"3. f1b5 a7a6",
+					"4. b5a4 g8f6",
+					"5. e1g1 f8e7",
+					"6. f1e1 b7b5",
+					"7. a4b3 d7d6",
+					"8. c2c3 e8g8",
+					"9. h2h3",
+				},
+			},
+			args: args{
+				boardInFEN: kiwipete,
+			},
+			want: strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"8rxxxkxxr 2. g1f3 b8c6\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"7pxppqpbx 3. f1b5 a7a6\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"6bnxxpnpx 4. b5a4 g8f6\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"5xxxPNxxx 5. e1g1 f8e7\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"4xpxxPxxx 6. f1e1 b7b5\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"3xxNxxQxp 7. a4b3 d7d6\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"2PPPBBPPP 8. c2c3 e8g8\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				"1RxxxKxxR 9. h2h3\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				strings.Repeat(" ", 9) + "\n" +
+				" abcdefgh",
+		},
 	} {
 		storage, err := uci.DecodePieceStorage(
 			data.args.boardInFEN,
@@ -705,6 +864,7 @@ func TestPieceStorageEncoderEncodePieceStorage(test *testing.T) {
 			colorizer:   data.fields.colorizer,
 			topColor:    data.fields.topColor,
 			pieceWidth:  data.fields.pieceWidth,
+			panel:       data.fields.panel,
 		}
 		got := encoder.EncodePieceStorage(storage)
 
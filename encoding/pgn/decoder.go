@@ -0,0 +1,174 @@
+package pgn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+type gameBuilder struct {
+	game    Game
+	tags    map[string]string
+	storage models.PieceStorage
+	color   models.Color
+	isEmpty bool
+}
+
+func newGameBuilder() *gameBuilder {
+	return &gameBuilder{
+		tags:    make(map[string]string),
+		color:   models.White,
+		isEmpty: true,
+	}
+}
+
+func (builder *gameBuilder) addTag(name string, value string) {
+	builder.tags[name] = value
+	builder.isEmpty = false
+
+	header := &builder.game.Header
+	switch name {
+	case "Event":
+		header.Event = value
+	case "Site":
+		header.Site = value
+	case "Date":
+		// unknown parts of a date lead to a zero date
+		header.Date, _ = time.Parse("2006.01.02", value) // nolint: gosec
+	case "Round":
+		header.Round = value
+	case "White":
+		header.White = value
+	case "Black":
+		header.Black = value
+	case "Result":
+		builder.game.Result = Result(value)
+	case "Termination":
+		builder.game.Termination = value
+	case "SetUp", "FEN":
+		// they are processed on decoding of an initial position
+	default:
+		if builder.game.Tags == nil {
+			builder.game.Tags = make(map[string]string)
+		}
+
+		builder.game.Tags[name] = value
+	}
+}
+
+func (builder *gameBuilder) addMove(text string) error {
+	if builder.game.InitialStorage == nil {
+		if err := builder.decodeInitialStorage(); err != nil {
+			return err // don't wrap
+		}
+	}
+
+	move, err := san.DecodeMove(builder.storage, builder.color, text)
+	if err != nil {
+		number := len(builder.game.Moves) + 1
+		return fmt.Errorf("unable to decode the move #%d (%s): %s", number, text, err)
+	}
+
+	// a promotion without a chosen kind is allowed for compatibility
+	move = move.WithDefaultPromotion(builder.storage)
+
+	builder.game.Moves = append(builder.game.Moves, move)
+	builder.storage = move.Apply(builder.storage)
+	builder.color = builder.color.Negative()
+	builder.isEmpty = false
+
+	return nil
+}
+
+func (builder *gameBuilder) decodeInitialStorage() error {
+	position := StandardPosition
+	if fen, ok := builder.tags["FEN"]; ok {
+		fields := strings.Fields(fen)
+		if len(fields) == 0 {
+			return errors.New("empty FEN")
+		}
+		if len(fields) > 1 && fields[1] != "w" {
+			return errors.New("unsupported FEN: the white color should move first")
+		}
+
+		position = fields[0]
+	}
+
+	storage, err := uci.DecodePieceStorage(
+		position,
+		pieces.NewPiece,
+		models.NewBoard,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to decode the initial position: %s", err)
+	}
+
+	builder.game.InitialStorage = storage
+	builder.storage = storage
+	return nil
+}
+
+func (builder *gameBuilder) build(result Result) (Game, error) {
+	if builder.game.InitialStorage == nil {
+		if err := builder.decodeInitialStorage(); err != nil {
+			return Game{}, err // don't wrap
+		}
+	}
+	if result != "" {
+		builder.game.Result = result
+	}
+	if builder.game.Result == "" {
+		builder.game.Result = Unknown
+	}
+
+	return builder.game, nil
+}
+
+// DecodeGames ...
+//
+// It supports several games in the text. Initial positions are taken
+// from FEN tags or are standard.
+func DecodeGames(text string) ([]Game, error) {
+	tokens, err := Tokenize(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to tokenize the text: %s", err)
+	}
+
+	var games []Game
+	builder := newGameBuilder()
+	for _, token := range tokens {
+		switch token.Kind {
+		case TagToken:
+			builder.addTag(token.Name, token.Value)
+		case MoveToken:
+			err = builder.addMove(token.Value)
+		case ResultToken:
+			var game Game
+			if game, err = builder.build(Result(token.Value)); err == nil {
+				games = append(games, game)
+				builder = newGameBuilder()
+			}
+		}
+		if err != nil {
+			number := len(games) + 1
+			return nil, fmt.Errorf("unable to decode the game #%d: %s", number, err)
+		}
+	}
+	if !builder.isEmpty {
+		game, err := builder.build("")
+		if err != nil {
+			number := len(games) + 1
+			return nil, fmt.Errorf("unable to decode the game #%d: %s", number, err)
+		}
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,200 @@
+package pgn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+func TestDecodeGames(test *testing.T) {
+	type wantGame struct {
+		header      Header
+		fen         string
+		moves       []string
+		result      Result
+		termination string
+	}
+	type args struct {
+		text string
+	}
+	type data struct {
+		args      args
+		wantGames []wantGame
+		wantErr   bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{`[Event "First"]
+[Date "2020.03.01"]
+[White "Human"]
+[Black "Computer"]
+[Result "1-0"]
+[Termination "normal"]
+[SetUp "1"]
+[FEN "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1"]
+
+1. Ra8# 1-0
+
+[Event "Second"]
+[Date "????.??.??"]
+
+1. e3 e6 2. Qh5 *
+
+1. Nf3`},
+			wantGames: []wantGame{
+				{
+					header: Header{
+						Event: "First",
+						Date:  time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+						White: "Human",
+						Black: "Computer",
+					},
+					fen:         "6k1/5ppp/8/8/8/8/8/R3K3",
+					moves:       []string{"a1a8"},
+					result:      WhiteWin,
+					termination: "normal",
+				},
+				{
+					header: Header{Event: "Second"},
+					fen:    StandardPosition,
+					moves:  []string{"e2e3", "e7e6", "d1h5"},
+					result: Unknown,
+				},
+				{
+					header: Header{},
+					fen:    StandardPosition,
+					moves:  []string{"g1f3"},
+					result: Unknown,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{`[Event "Empty"]
+[FEN "4k3/8/8/8/8/8/8/4K3"]
+1/2-1/2`},
+			wantGames: []wantGame{
+				{
+					header: Header{Event: "Empty"},
+					fen:    "4k3/8/8/8/8/8/8/4K3",
+					moves:  nil,
+					result: Draw,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{`[FEN "3k1/P4/5/5/4K w - - 0 1"]
+
+1. a5=N Kd4 2. Ke2 Kd5 3. Nb3 *`},
+			wantGames: []wantGame{
+				{
+					header: Header{},
+					fen:    "3k1/P4/5/5/4K",
+					moves:  []string{"a4a5", "d5d4", "e1e2", "d4d5", "a5b3"},
+					result: Unknown,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			args:      args{"1. e3 e6 2. Qh6 *"},
+			wantGames: nil,
+			wantErr:   true,
+		},
+		{
+			args:      args{`[FEN "4k3/8/8/8/8/8/8/4K3 b - - 0 1"] 1... Kd8 *`},
+			wantGames: nil,
+			wantErr:   true,
+		},
+		{
+			args:      args{"1. e3 {"},
+			wantGames: nil,
+			wantErr:   true,
+		},
+	} {
+		gotGames, gotErr := DecodeGames(data.args.text)
+
+		if len(gotGames) != len(data.wantGames) {
+			test.Fail()
+			continue
+		}
+		for index, gotGame := range gotGames {
+			wantGame := data.wantGames[index]
+			if gotGame.Header != wantGame.header {
+				test.Fail()
+			}
+			if uci.EncodePieceStorage(gotGame.InitialStorage) != wantGame.fen {
+				test.Fail()
+			}
+
+			var gotMoves []string
+			for _, move := range gotGame.Moves {
+				gotMoves = append(gotMoves, uci.EncodeMove(move.Move))
+			}
+			if len(gotMoves) != len(wantGame.moves) {
+				test.Fail()
+				continue
+			}
+			for moveIndex, move := range gotMoves {
+				if move != wantGame.moves[moveIndex] {
+					test.Fail()
+				}
+			}
+
+			if gotGame.Result != wantGame.result {
+				test.Fail()
+			}
+			if gotGame.Termination != wantGame.termination {
+				test.Fail()
+			}
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestDecodeGames_withEncodeGame(test *testing.T) {
+	games, err := DecodeGames("1. e3 e6 2. Qh5 Nf6 3. Qxf7# 1-0")
+	if err != nil || len(games) != 1 {
+		test.Fatal(err)
+	}
+
+	text, err := EncodeGame(games[0])
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	gotGames, gotErr := DecodeGames(text)
+
+	if len(gotGames) != 1 || len(gotGames[0].Moves) != 5 {
+		test.Fail()
+	}
+	if gotGames[0].Result != WhiteWin {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
+
+func TestDecodeGames_withTags(test *testing.T) {
+	gotGames, gotErr := DecodeGames(`[Event "Test"]
+[Alpha "1"]
+[Zeta ""]
+
+*`)
+
+	if len(gotGames) != 1 || len(gotGames[0].Tags) != 2 {
+		test.FailNow()
+	}
+	if gotGames[0].Tags["Alpha"] != "1" || gotGames[0].Tags["Zeta"] != "" {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
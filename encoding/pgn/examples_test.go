@@ -0,0 +1,66 @@
+package pgn_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func ExampleEncodeGame() {
+	const fen = "6k1/5ppp/8/8/8/8/8/R3K3"
+	storage, _ := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	move, _ := uci.DecodeMove("a1a8")
+	text, _ := pgn.EncodeGame(pgn.Game{
+		Header: pgn.Header{
+			Event: "Example",
+			Date:  time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			White: "Human",
+			Black: "Computer",
+		},
+		InitialStorage: storage,
+		Moves:          []climodels.Move{{Move: move}},
+		Result:         pgn.WhiteWin,
+	})
+	fmt.Printf("%v", text)
+
+	// Output:
+	// [Event "Example"]
+	// [Site "?"]
+	// [Date "2020.03.01"]
+	// [Round "?"]
+	// [White "Human"]
+	// [Black "Computer"]
+	// [Result "1-0"]
+	// [SetUp "1"]
+	// [FEN "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1"]
+	//
+	// 1. Ra8# 1-0
+}
+
+func ExampleDecodeGames() {
+	games, _ := pgn.DecodeGames(`[Event "Example"]
+[FEN "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1"]
+
+1. Ra8# 1-0`)
+	for _, game := range games {
+		var moves []string
+		for _, move := range game.Moves {
+			moves = append(moves, uci.EncodeMove(move.Move))
+		}
+
+		fmt.Printf(
+			"%s: %s %v %s\n",
+			game.Header.Event,
+			uci.EncodePieceStorage(game.InitialStorage),
+			moves,
+			game.Result,
+		)
+	}
+
+	// Output: Example: 6k1/5ppp/8/8/8/8/8/R3K3 [a1a8] 1-0
+}
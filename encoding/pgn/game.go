@@ -0,0 +1,161 @@
+package pgn
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+const (
+	// StandardPosition ...
+	//
+	// It's an initial position of classical chess in FEN.
+	StandardPosition = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR"
+
+	maximalLineLength = 79
+	unknownTag        = "?"
+	unknownDate       = "????.??.??"
+)
+
+// Header ...
+//
+// It contains the Seven Tag Roster except a result.
+type Header struct {
+	Event string
+	Site  string
+	Date  time.Time
+	Round string
+	White string
+	Black string
+}
+
+// Game ...
+type Game struct {
+	Header         Header
+	InitialStorage models.PieceStorage
+	Moves          []climodels.Move
+	Result         Result
+	// it's optional and contains a standard value of the tag
+	// (e.g. "normal" or "time forfeit")
+	Termination string
+	// it's optional and contains additional tags (e.g. settings of a session);
+	// they are encoded after the standard ones in the order of names
+	Tags map[string]string
+}
+
+// EncodeGame ...
+func EncodeGame(game Game) (string, error) {
+	var date string
+	if !game.Header.Date.IsZero() {
+		date = game.Header.Date.Format("2006.01.02")
+	} else {
+		date = unknownDate
+	}
+
+	result := game.Result
+	if result == "" {
+		result = Unknown
+	}
+
+	tags := []string{
+		encodeTag("Event", game.Header.Event),
+		encodeTag("Site", game.Header.Site),
+		encodeTag("Date", date),
+		encodeTag("Round", game.Header.Round),
+		encodeTag("White", game.Header.White),
+		encodeTag("Black", game.Header.Black),
+		encodeTag("Result", string(result)),
+	}
+	if game.Termination != "" {
+		tags = append(tags, encodeTag("Termination", game.Termination))
+	}
+	position := uci.EncodePieceStorage(game.InitialStorage)
+	if position != StandardPosition {
+		// the white color to move, without castlings and en passant
+		fen := position + " w - - 0 1"
+		tags = append(tags, encodeTag("SetUp", "1"), encodeTag("FEN", fen))
+	}
+
+	var extraNames []string
+	for name := range game.Tags {
+		extraNames = append(extraNames, name)
+	}
+	sort.Strings(extraNames)
+	for _, name := range extraNames {
+		// empty values of additional tags are meaningful
+		tags = append(tags, encodeRawTag(name, game.Tags[name]))
+	}
+
+	movetext, err := encodeMovetext(game.InitialStorage, game.Moves, result)
+	if err != nil {
+		return "", err // don't wrap
+	}
+
+	return strings.Join(tags, "\n") + "\n\n" + movetext + "\n", nil
+}
+
+func encodeTag(name string, value string) string {
+	if value == "" {
+		value = unknownTag
+	}
+
+	return encodeRawTag(name, value)
+}
+
+func encodeRawTag(name string, value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return fmt.Sprintf("[%s \"%s\"]", name, value)
+}
+
+func encodeMovetext(
+	storage models.PieceStorage,
+	moves []climodels.Move,
+	result Result,
+) (string, error) {
+	var tokens []string
+	for index, move := range moves {
+		if index%2 == 0 {
+			number := strconv.Itoa(index/2+1) + "."
+			tokens = append(tokens, number)
+		}
+
+		text, err := san.EncodeMove(storage, move)
+		if err != nil {
+			return "", fmt.Errorf(
+				"unable to encode the move %s: %s",
+				uci.EncodeMove(move.Move),
+				err,
+			)
+		}
+
+		tokens = append(tokens, text)
+		storage = move.Apply(storage)
+	}
+	tokens = append(tokens, string(result))
+
+	var lines []string
+	var currentLine string
+	for _, token := range tokens {
+		if len(currentLine) != 0 &&
+			len(currentLine)+len(token)+1 > maximalLineLength {
+			lines = append(lines, currentLine)
+			currentLine = ""
+		}
+		if len(currentLine) != 0 {
+			currentLine += " "
+		}
+
+		currentLine += token
+	}
+	lines = append(lines, currentLine)
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,204 @@
+package pgn
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func TestEncodeGame(test *testing.T) {
+	type args struct {
+		header      Header
+		fen         string
+		moves       []string
+		result      Result
+		termination string
+	}
+	type data struct {
+		args    args
+		want    string
+		wantErr bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				header: Header{
+					Event: "Test \"event\"",
+					Site:  "Test site",
+					Date:  time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+					Round: "1",
+					White: "Human",
+					Black: "Computer",
+				},
+				fen:    StandardPosition,
+				moves:  []string{"e2e3", "e7e6", "d1h5"},
+				result: Unknown,
+			},
+			want: `[Event "Test \"event\""]
+[Site "Test site"]
+[Date "2020.03.01"]
+[Round "1"]
+[White "Human"]
+[Black "Computer"]
+[Result "*"]
+
+1. e3 e6 2. Qh5 *
+`,
+			wantErr: false,
+		},
+		{
+			args: args{
+				header: Header{},
+				fen:    "6k1/5ppp/8/8/8/8/8/R3K3",
+				moves:  []string{"a1a8"},
+				result: WhiteWin,
+			},
+			want: `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "1-0"]
+[SetUp "1"]
+[FEN "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1"]
+
+1. Ra8# 1-0
+`,
+			wantErr: false,
+		},
+		{
+			args: args{
+				header:      Header{},
+				fen:         "6k1/5ppp/8/8/8/8/8/R3K3",
+				moves:       []string{"a1a8"},
+				result:      WhiteWin,
+				termination: "normal",
+			},
+			want: `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "1-0"]
+[Termination "normal"]
+[SetUp "1"]
+[FEN "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1"]
+
+1. Ra8# 1-0
+`,
+			wantErr: false,
+		},
+		{
+			args: args{
+				header: Header{},
+				fen:    "6k1/5ppp/8/8/8/8/8/R3K3",
+				moves:  []string{"b1b2"},
+				result: "",
+			},
+			want:    "",
+			wantErr: true,
+		},
+	} {
+		storage, err := uci.DecodePieceStorage(
+			data.args.fen,
+			pieces.NewPiece,
+			models.NewBoard,
+		)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		var moves []climodels.Move
+		for _, text := range data.args.moves {
+			move, err := uci.DecodeMove(text)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			moves = append(moves, climodels.Move{Move: move})
+		}
+
+		got, gotErr := EncodeGame(Game{
+			Header:         data.args.header,
+			InitialStorage: storage,
+			Moves:          moves,
+			Result:         data.args.result,
+			Termination:    data.args.termination,
+		})
+
+		if got != data.want {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestEncodeMovetext(test *testing.T) {
+	storage, err := uci.DecodePieceStorage(
+		"4k3/8/8/8/8/8/8/R3K3",
+		pieces.NewPiece,
+		models.NewBoard,
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var moves []climodels.Move
+	for i := 0; i < 20; i++ {
+		for _, text := range []string{"a1a2", "e8d8", "a2a1", "d8e8"} {
+			move, _ := uci.DecodeMove(text) // nolint: gosec
+			moves = append(moves, climodels.Move{Move: move})
+		}
+	}
+
+	got, gotErr := encodeMovetext(storage, moves, Draw)
+
+	for _, line := range strings.Split(got, "\n") {
+		if len(line) > maximalLineLength {
+			test.Fail()
+		}
+	}
+	if !strings.HasPrefix(got, "1. Ra2 Kd8 2. Ra1 Ke8 3. Ra2") {
+		test.Fail()
+	}
+	if !strings.HasSuffix(got, "40. Ra1 Ke8 1/2-1/2") {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
+
+func TestEncodeGame_withTags(test *testing.T) {
+	storage, err := uci.DecodePieceStorage(
+		StandardPosition,
+		pieces.NewPiece,
+		models.NewBoard,
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	got, gotErr := EncodeGame(Game{
+		InitialStorage: storage,
+		Tags:           map[string]string{"Zeta": "", "Alpha": "1"},
+	})
+
+	want := "[Result \"*\"]\n[Alpha \"1\"]\n[Zeta \"\"]\n\n*\n"
+	if !strings.HasSuffix(got, want) {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
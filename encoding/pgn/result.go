@@ -0,0 +1,25 @@
+package pgn
+
+import (
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// Result ...
+type Result string
+
+// ...
+const (
+	WhiteWin Result = "1-0"
+	BlackWin Result = "0-1"
+	Draw     Result = "1/2-1/2"
+	Unknown  Result = "*"
+)
+
+// NewWin ...
+func NewWin(winner models.Color) Result {
+	if winner == models.White {
+		return WhiteWin
+	}
+
+	return BlackWin
+}
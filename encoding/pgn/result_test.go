@@ -0,0 +1,34 @@
+package pgn
+
+import (
+	"testing"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestNewWin(test *testing.T) {
+	type args struct {
+		winner models.Color
+	}
+	type data struct {
+		args args
+		want Result
+	}
+
+	for _, data := range []data{
+		{
+			args: args{models.Black},
+			want: BlackWin,
+		},
+		{
+			args: args{models.White},
+			want: WhiteWin,
+		},
+	} {
+		got := NewWin(data.args.winner)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package pgn
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// TokenKind ...
+type TokenKind int
+
+// ...
+const (
+	TagToken TokenKind = iota
+	MoveToken
+	ResultToken
+)
+
+// Token ...
+type Token struct {
+	Kind TokenKind
+	// it's set for tags only
+	Name  string
+	Value string
+}
+
+// Tokenize ...
+//
+// It skips comments, variations, move numbers, numeric annotation glyphs
+// and escaped lines.
+func Tokenize(text string) ([]Token, error) {
+	var tokens []Token
+	symbols := []rune(text)
+	for index := 0; index < len(symbols); {
+		symbol := symbols[index]
+		switch {
+		case unicode.IsSpace(symbol):
+			index++
+		case symbol == '%' && (index == 0 || symbols[index-1] == '\n'):
+			index = skipLine(symbols, index)
+		case symbol == ';':
+			index = skipLine(symbols, index)
+		case symbol == '{':
+			end := indexOf(symbols, index, '}')
+			if end == -1 {
+				return nil, errors.New("unterminated comment")
+			}
+
+			index = end + 1
+		case symbol == '(':
+			end, err := skipVariation(symbols, index)
+			if err != nil {
+				return nil, err // don't wrap
+			}
+
+			index = end
+		case symbol == '[':
+			token, end, err := readTag(symbols, index)
+			if err != nil {
+				return nil, err // don't wrap
+			}
+
+			tokens = append(tokens, token)
+			index = end
+		case symbol == '$':
+			index = skipSymbol(symbols, index+1)
+		default:
+			end := skipSymbol(symbols, index)
+			if end == index {
+				return nil, errors.New("unexpected symbol " + string(symbol))
+			}
+
+			if token, ok := makeToken(string(symbols[index:end])); ok {
+				tokens = append(tokens, token)
+			}
+
+			index = end
+		}
+	}
+
+	return tokens, nil
+}
+
+func makeToken(text string) (token Token, ok bool) {
+	switch text {
+	case string(WhiteWin), string(BlackWin), string(Draw), string(Unknown):
+		return Token{Kind: ResultToken, Value: text}, true
+	}
+
+	// skip a move number, e.g. "12." or "12...e5"
+	text = strings.TrimLeft(text, "0123456789")
+	text = strings.TrimLeft(text, ".")
+	if text == "" {
+		return Token{}, false
+	}
+
+	return Token{Kind: MoveToken, Value: text}, true
+}
+
+func readTag(symbols []rune, index int) (token Token, end int, err error) {
+	index++ // skip the opening bracket
+	index = skipSpaces(symbols, index)
+	nameEnd := skipSymbol(symbols, index)
+	if nameEnd == index {
+		return Token{}, 0, errors.New("tag without a name")
+	}
+
+	name := string(symbols[index:nameEnd])
+	index = skipSpaces(symbols, nameEnd)
+	if index == len(symbols) || symbols[index] != '"' {
+		return Token{}, 0, errors.New("tag without a value")
+	}
+
+	var value []rune
+	for index++; ; index++ {
+		if index == len(symbols) {
+			return Token{}, 0, errors.New("unterminated tag value")
+		}
+
+		symbol := symbols[index]
+		if symbol == '"' {
+			break
+		}
+		if symbol == '\\' && index+1 < len(symbols) {
+			index++
+			symbol = symbols[index]
+		}
+
+		value = append(value, symbol)
+	}
+
+	index = skipSpaces(symbols, index+1)
+	if index == len(symbols) || symbols[index] != ']' {
+		return Token{}, 0, errors.New("unterminated tag")
+	}
+
+	token = Token{Kind: TagToken, Name: name, Value: string(value)}
+	return token, index + 1, nil
+}
+
+func skipVariation(symbols []rune, index int) (end int, err error) {
+	var depth int
+	for ; index < len(symbols); index++ {
+		switch symbols[index] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return index + 1, nil
+			}
+		case '{':
+			commentEnd := indexOf(symbols, index, '}')
+			if commentEnd == -1 {
+				return 0, errors.New("unterminated comment")
+			}
+
+			index = commentEnd
+		}
+	}
+
+	return 0, errors.New("unterminated variation")
+}
+
+func skipLine(symbols []rune, index int) int {
+	end := indexOf(symbols, index, '\n')
+	if end == -1 {
+		return len(symbols)
+	}
+
+	return end + 1
+}
+
+func skipSpaces(symbols []rune, index int) int {
+	for index < len(symbols) && unicode.IsSpace(symbols[index]) {
+		index++
+	}
+
+	return index
+}
+
+func skipSymbol(symbols []rune, index int) int {
+	for index < len(symbols) {
+		symbol := symbols[index]
+		if unicode.IsSpace(symbol) || strings.ContainsRune("[]{}();\"", symbol) {
+			break
+		}
+
+		index++
+	}
+
+	return index
+}
+
+func indexOf(symbols []rune, index int, symbol rune) int {
+	for ; index < len(symbols); index++ {
+		if symbols[index] == symbol {
+			return index
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,96 @@
+package pgn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(test *testing.T) {
+	type args struct {
+		text string
+	}
+	type data struct {
+		args       args
+		wantTokens []Token
+		wantErr    bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{`[Event "Test \"event\""]
+[White "Human"]
+
+1. e4 e5 2.Nf3 {a comment} Nc6 (2... Nf6 {a nested comment} (2... d6))
+3. Bb5 $1 a6; a line comment
+% an escaped line
+3...b5 1-0`},
+			wantTokens: []Token{
+				{Kind: TagToken, Name: "Event", Value: `Test "event"`},
+				{Kind: TagToken, Name: "White", Value: "Human"},
+				{Kind: MoveToken, Value: "e4"},
+				{Kind: MoveToken, Value: "e5"},
+				{Kind: MoveToken, Value: "Nf3"},
+				{Kind: MoveToken, Value: "Nc6"},
+				{Kind: MoveToken, Value: "Bb5"},
+				{Kind: MoveToken, Value: "a6"},
+				{Kind: MoveToken, Value: "b5"},
+				{Kind: ResultToken, Value: "1-0"},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{"1. e4 * 1. d4 1/2-1/2"},
+			wantTokens: []Token{
+				{Kind: MoveToken, Value: "e4"},
+				{Kind: ResultToken, Value: "*"},
+				{Kind: MoveToken, Value: "d4"},
+				{Kind: ResultToken, Value: "1/2-1/2"},
+			},
+			wantErr: false,
+		},
+		{
+			args:       args{""},
+			wantTokens: nil,
+			wantErr:    false,
+		},
+		{
+			args:       args{"1. e4 {a comment"},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			args:       args{"1. e4 (1. d4"},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			args:       args{`[Event "Test`},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			args:       args{`[Event]`},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			args:       args{`[Event "Test"`},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+		{
+			args:       args{`1. e4 ) e5`},
+			wantTokens: nil,
+			wantErr:    true,
+		},
+	} {
+		gotTokens, gotErr := Tokenize(data.args.text)
+
+		if !reflect.DeepEqual(gotTokens, data.wantTokens) {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
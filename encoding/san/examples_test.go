@@ -0,0 +1,30 @@
+package san_test
+
+import (
+	"fmt"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func ExampleEncodeMove() {
+	const fen = "6k1/5ppp/8/8/8/8/8/R3K3"
+	storage, _ := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	move, _ := uci.DecodeMove("a1a8")
+	text, _ := san.EncodeMove(storage, climodels.Move{Move: move})
+	fmt.Printf("%v\n", text)
+
+	// Output: Ra8#
+}
+
+func ExampleDecodeMove() {
+	const fen = "6k1/5ppp/8/8/8/8/8/R3K3"
+	storage, _ := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	move, _ := san.DecodeMove(storage, models.White, "Ra8#")
+	fmt.Printf("%v\n", uci.EncodeMove(move.Move))
+
+	// Output: a1a8
+}
@@ -0,0 +1,304 @@
+package san
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+// EncodeKind ...
+//
+// It returns an empty string for a pawn.
+func EncodeKind(kind models.Kind) string {
+	var text string
+	switch kind {
+	case models.King:
+		text = "K"
+	case models.Queen:
+		text = "Q"
+	case models.Rook:
+		text = "R"
+	case models.Bishop:
+		text = "B"
+	case models.Knight:
+		text = "N"
+	}
+
+	return text
+}
+
+// DecodeKind ...
+func DecodeKind(text string) (models.Kind, error) {
+	var kind models.Kind
+	switch text {
+	case "K":
+		kind = models.King
+	case "Q":
+		kind = models.Queen
+	case "R":
+		kind = models.Rook
+	case "B":
+		kind = models.Bishop
+	case "N":
+		kind = models.Knight
+	default:
+		return 0, errors.New("incorrect kind")
+	}
+
+	return kind, nil
+}
+
+// DecodeMove ...
+//
+// It resolves the move among correct moves of the color. Suffixes
+// of a check, a checkmate and annotations are ignored. A promotion
+// (e.g. "e8=Q" or "e8Q") is optional, so the result can be a promotion
+// without a chosen kind.
+func DecodeMove(
+	storage models.PieceStorage,
+	color models.Color,
+	text string,
+) (climodels.Move, error) {
+	text = strings.TrimRight(text, "+#!?")
+
+	var promotion string
+	if index := strings.IndexByte(text, '='); index != -1 {
+		text, promotion = text[:index], text[index+1:]
+	} else if length := len(text); length > 2 &&
+		strings.IndexByte("QRBN", text[length-1]) != -1 {
+		text, promotion = text[:length-1], text[length-1:]
+	}
+	if strings.HasPrefix(text, "O-O") || strings.HasPrefix(text, "0-0") {
+		return climodels.Move{}, errors.New("castling isn't supported")
+	}
+	if len(text) < 2 {
+		return climodels.Move{}, errors.New("too short move")
+	}
+
+	var promotionKind models.Kind
+	if promotion != "" {
+		var err error
+		promotionKind, err = DecodeKind(promotion)
+		if err != nil || !climodels.IsPromotionKind(promotionKind) {
+			return climodels.Move{}, errors.New("incorrect promotion")
+		}
+	}
+
+	kind := models.Pawn
+	if parsedKind, err := DecodeKind(text[:1]); err == nil {
+		kind = parsedKind
+		text = text[1:]
+	}
+
+	finishIndex := strings.LastIndexAny(text, "abcdefghijklmnopqrstuvwxyz")
+	if finishIndex == -1 {
+		return climodels.Move{}, errors.New("no finish position")
+	}
+
+	finish, err := uci.DecodePosition(text[finishIndex:])
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("incorrect finish position: %s", err)
+	}
+
+	disambiguation := strings.TrimSuffix(text[:finishIndex], "x")
+	moves, err := CorrectMoves(storage, color)
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("unable to generate moves: %s", err)
+	}
+
+	var candidates []models.Move
+	for _, move := range moves {
+		if move.Finish != finish {
+			continue
+		}
+
+		piece, _ := storage.Piece(move.Start)
+		if piece.Kind() != kind {
+			continue
+		}
+
+		start := uci.EncodePosition(move.Start)
+		if !matchDisambiguation(start, disambiguation) {
+			continue
+		}
+
+		candidates = append(candidates, move)
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return climodels.Move{}, errors.New("no such move")
+	case len(candidates) > 1:
+		return climodels.Move{}, errors.New("ambiguous move")
+	}
+
+	move := climodels.Move{Move: candidates[0], Promotion: promotionKind}
+	isPromotion := climodels.IsPromotion(storage, move.Move)
+	if promotionKind != models.King && !isPromotion {
+		return climodels.Move{}, errors.New("unexpected promotion")
+	}
+
+	return move, nil
+}
+
+// EncodeMove ...
+//
+// It expects a correct move.
+func EncodeMove(
+	storage models.PieceStorage,
+	move climodels.Move,
+) (string, error) {
+	piece, ok := storage.Piece(move.Start)
+	if !ok {
+		return "", errors.New("no piece")
+	}
+
+	_, isCapture := storage.Piece(move.Finish)
+	start := uci.EncodePosition(move.Start)
+	finish := uci.EncodePosition(move.Finish)
+
+	var text string
+	if piece.Kind() == models.Pawn {
+		if isCapture {
+			text = start[:1] + "x"
+		}
+		text += finish
+
+		nextStorage := move.Apply(storage)
+		nextPiece, _ := nextStorage.Piece(move.Finish)
+		if kind := nextPiece.Kind(); kind != models.Pawn {
+			text += "=" + EncodeKind(kind)
+		}
+	} else {
+		disambiguation, err := disambiguate(storage, piece, move.Move)
+		if err != nil {
+			return "", err // don't wrap
+		}
+
+		text = EncodeKind(piece.Kind()) + disambiguation
+		if isCapture {
+			text += "x"
+		}
+		text += finish
+	}
+
+	nextColor := piece.Color().Negative()
+	suffix, err := checkSuffix(move.Apply(storage), nextColor)
+	if err != nil {
+		return "", err // don't wrap
+	}
+
+	return text + suffix, nil
+}
+
+func matchDisambiguation(start string, disambiguation string) bool {
+	switch {
+	case disambiguation == "":
+		return true
+	case disambiguation[0] >= 'a' && disambiguation[0] <= 'z':
+		// a file, maybe with a rank
+		return strings.HasPrefix(start, disambiguation)
+	default:
+		// a rank only
+		return start[1:] == disambiguation
+	}
+}
+
+func disambiguate(
+	storage models.PieceStorage,
+	piece models.Piece,
+	move models.Move,
+) (string, error) {
+	moves, err := CorrectMoves(storage, piece.Color())
+	if err != nil {
+		return "", err // don't wrap
+	}
+
+	var isAmbiguous, isFileAmbiguous, isRankAmbiguous bool
+	for _, otherMove := range moves {
+		if otherMove.Finish != move.Finish || otherMove.Start == move.Start {
+			continue
+		}
+
+		otherPiece, _ := storage.Piece(otherMove.Start)
+		if otherPiece.Kind() != piece.Kind() {
+			continue
+		}
+
+		isAmbiguous = true
+		if otherMove.Start.File == move.Start.File {
+			isFileAmbiguous = true
+		}
+		if otherMove.Start.Rank == move.Start.Rank {
+			isRankAmbiguous = true
+		}
+	}
+
+	start := uci.EncodePosition(move.Start)
+	switch {
+	case !isAmbiguous:
+		return "", nil
+	case !isFileAmbiguous:
+		return start[:1], nil
+	case !isRankAmbiguous:
+		return start[1:], nil
+	default:
+		return start, nil
+	}
+}
+
+func checkSuffix(
+	storage models.PieceStorage,
+	color models.Color,
+) (string, error) {
+	if !IsCheck(storage, color) {
+		return "", nil
+	}
+
+	moves, err := CorrectMoves(storage, color)
+	if err != nil {
+		return "", err // don't wrap
+	}
+	if len(moves) == 0 {
+		return "#", nil
+	}
+
+	return "+", nil
+}
+
+// IsCheck ...
+//
+// It detects whether a king of the color is under attack.
+func IsCheck(storage models.PieceStorage, color models.Color) bool {
+	generator := models.MoveGenerator{}
+	_, err := generator.MovesForColor(storage, color.Negative())
+	return err == models.ErrKingCapture
+}
+
+// CorrectMoves ...
+//
+// It returns moves, after which a king of the color isn't under attack.
+func CorrectMoves(
+	storage models.PieceStorage,
+	color models.Color,
+) ([]models.Move, error) {
+	generator := models.MoveGenerator{}
+	moves, err := generator.MovesForColor(storage, color)
+	if err != nil {
+		return nil, err // don't wrap
+	}
+
+	var correctMoves []models.Move
+	for _, move := range moves {
+		nextStorage := storage.ApplyMove(move)
+		if !IsCheck(nextStorage, color) {
+			correctMoves = append(correctMoves, move)
+		}
+	}
+
+	return correctMoves, nil
+}
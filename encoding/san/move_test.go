@@ -0,0 +1,447 @@
+package san
+
+import (
+	"testing"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func TestEncodeKind(test *testing.T) {
+	type args struct {
+		kind models.Kind
+	}
+	type data struct {
+		args args
+		want string
+	}
+
+	for _, data := range []data{
+		{
+			args: args{models.King},
+			want: "K",
+		},
+		{
+			args: args{models.Queen},
+			want: "Q",
+		},
+		{
+			args: args{models.Rook},
+			want: "R",
+		},
+		{
+			args: args{models.Bishop},
+			want: "B",
+		},
+		{
+			args: args{models.Knight},
+			want: "N",
+		},
+		{
+			args: args{models.Pawn},
+			want: "",
+		},
+	} {
+		got := EncodeKind(data.args.kind)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestDecodeKind(test *testing.T) {
+	type args struct {
+		text string
+	}
+	type data struct {
+		args     args
+		wantKind models.Kind
+		wantErr  bool
+	}
+
+	for _, data := range []data{
+		{
+			args:     args{"K"},
+			wantKind: models.King,
+			wantErr:  false,
+		},
+		{
+			args:     args{"Q"},
+			wantKind: models.Queen,
+			wantErr:  false,
+		},
+		{
+			args:     args{"R"},
+			wantKind: models.Rook,
+			wantErr:  false,
+		},
+		{
+			args:     args{"B"},
+			wantKind: models.Bishop,
+			wantErr:  false,
+		},
+		{
+			args:     args{"N"},
+			wantKind: models.Knight,
+			wantErr:  false,
+		},
+		{
+			args:     args{"P"},
+			wantKind: 0,
+			wantErr:  true,
+		},
+	} {
+		gotKind, gotErr := DecodeKind(data.args.text)
+
+		if gotKind != data.wantKind {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestDecodeMove(test *testing.T) {
+	type args struct {
+		fen   string
+		color models.Color
+		text  string
+	}
+	type data struct {
+		args          args
+		wantMove      string
+		wantPromotion models.Kind
+		wantErr       bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen:   "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+				color: models.White,
+				text:  "e3",
+			},
+			wantMove: "e2e3",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+				color: models.Black,
+				text:  "Nf6!?",
+			},
+			wantMove: "g8f6",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/3p4/4P3/8/8/4K3",
+				color: models.White,
+				text:  "exd5",
+			},
+			wantMove: "e4d5",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/8/8/4K3/R6R",
+				color: models.White,
+				text:  "Rad1",
+			},
+			wantMove: "a1d1",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/R7/8/4K3/R7",
+				color: models.White,
+				text:  "R1a2",
+			},
+			wantMove: "a1a2",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "6k1/8/8/8/Q2Q4/8/4K3/Q7",
+				color: models.White,
+				text:  "Qa4d1",
+			},
+			wantMove: "a4d1",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "6k1/5ppp/8/8/8/8/8/R3K3",
+				color: models.White,
+				text:  "Ra8#",
+			},
+			wantMove: "a1a8",
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:   "3k1/P4/5/5/4K",
+				color: models.White,
+				text:  "a5=Q+",
+			},
+			wantMove:      "a4a5",
+			wantPromotion: models.Queen,
+			wantErr:       false,
+		},
+		{
+			args: args{
+				fen:   "3k1/P4/5/5/4K",
+				color: models.White,
+				text:  "a5N",
+			},
+			wantMove:      "a4a5",
+			wantPromotion: models.Knight,
+			wantErr:       false,
+		},
+		{
+			// a promotion without a chosen kind
+			args: args{
+				fen:   "3k1/P4/5/5/4K",
+				color: models.White,
+				text:  "a5",
+			},
+			wantMove:      "a4a5",
+			wantPromotion: models.King,
+			wantErr:       false,
+		},
+		{
+			args: args{
+				fen:   "3k1/P4/5/5/4K",
+				color: models.White,
+				text:  "a5=K",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:   "3k1/P4/5/5/4K",
+				color: models.White,
+				text:  "Ke2=Q",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/8/8/4K3/R6R",
+				color: models.White,
+				text:  "Rd1",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/8/8/4K3/R6R",
+				color: models.White,
+				text:  "Nd1",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/8/8/8/R3K2R",
+				color: models.White,
+				text:  "O-O",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:   "4k3/8/8/8/8/8/8/R3K2R",
+				color: models.White,
+				text:  "R",
+			},
+			wantMove: "",
+			wantErr:  true,
+		},
+	} {
+		storage, err := uci.DecodePieceStorage(
+			data.args.fen,
+			pieces.NewPiece,
+			models.NewBoard,
+		)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		gotMove, gotErr := DecodeMove(storage, data.args.color, data.args.text)
+
+		var wantMove climodels.Move
+		if !data.wantErr {
+			wantMove.Move, err = uci.DecodeMove(data.wantMove)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			wantMove.Promotion = data.wantPromotion
+		}
+		if gotMove != wantMove {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestEncodeMove(test *testing.T) {
+	type args struct {
+		fen       string
+		move      string
+		promotion models.Kind
+	}
+	type data struct {
+		args    args
+		want    string
+		wantErr bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen:  "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+				move: "e2e3",
+			},
+			want:    "e3",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR",
+				move: "g1f3",
+			},
+			want:    "Nf3",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/3p4/4P3/8/8/4K3",
+				move: "e4d5",
+			},
+			want:    "exd5",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/8/8/8/4p3/4K3",
+				move: "e1e2",
+			},
+			want:    "Kxe2",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/8/8/8/4K3/R6R",
+				move: "a1d1",
+			},
+			want:    "Rad1",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/8/R7/8/4K3/R7",
+				move: "a1a2",
+			},
+			want:    "R1a2",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "6k1/8/8/8/Q2Q4/8/4K3/Q7",
+				move: "a4d1",
+			},
+			want:    "Qa4d1",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/8/8/8/8/R3K3",
+				move: "a1a8",
+			},
+			want:    "Ra8+",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "6k1/5ppp/8/8/8/8/8/R3K3",
+				move: "a1a8",
+			},
+			want:    "Ra8#",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:       "3k1/P4/5/5/4K",
+				move:      "a4a5",
+				promotion: models.Queen,
+			},
+			want:    "a5=Q+",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:       "3k1/P4/5/5/4K",
+				move:      "a4a5",
+				promotion: models.Knight,
+			},
+			want:    "a5=N",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:       "1r1k1/P4/5/5/4K",
+				move:      "a4b5",
+				promotion: models.Bishop,
+			},
+			want:    "axb5=B",
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:  "4k3/8/8/8/8/8/8/4K3",
+				move: "a1a2",
+			},
+			want:    "",
+			wantErr: true,
+		},
+	} {
+		storage, err := uci.DecodePieceStorage(
+			data.args.fen,
+			pieces.NewPiece,
+			models.NewBoard,
+		)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		move, err := uci.DecodeMove(data.args.move)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		got, gotErr := EncodeMove(storage, climodels.Move{
+			Move:      move,
+			Promotion: data.args.promotion,
+		})
+
+		if got != data.want {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// SetAutosavePath ...
+//
+// It sets a file, to which the game is saved in PGN after each move
+// (and after each change of moves by commands), so the game can be resumed
+// by Game.Resume() after a crash. The file is replaced atomically.
+func (game *Game) SetAutosavePath(path string) {
+	game.autosavePath = path
+}
+
+// errors of an autosave are displayed only, because they shouldn't stop
+// the game
+func (game *Game) autosave() {
+	if game.autosavePath == "" {
+		return
+	}
+
+	text, err := game.EncodePGN(nil)
+	if err == nil {
+		err = writeFileAtomically(game.autosavePath, []byte(text))
+	}
+	if err != nil {
+		fmt.Fprintf( // nolint: errcheck
+			game.writer,
+			"error: unable to autosave the game: %s\n",
+			err,
+		)
+	}
+}
+
+// it writes a temporary file in the same directory and renames it,
+// so the file is never left half-written
+func writeFileAtomically(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to create a temporary file: %s", err)
+	}
+	// it does nothing after a successful renaming
+	defer os.Remove(file.Name()) // nolint: errcheck
+
+	if _, err := file.Write(data); err != nil {
+		file.Close() // nolint: errcheck, gosec
+		return fmt.Errorf("unable to write a temporary file: %s", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close() // nolint: errcheck, gosec
+		return fmt.Errorf("unable to flush a temporary file: %s", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to close a temporary file: %s", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace the file: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+)
+
+func TestGamePlay_withAutosave(test *testing.T) {
+	directory, err := ioutil.TempDir("", "autosave")
+	if err != nil {
+		test.Fatal(err)
+	}
+	defer os.RemoveAll(directory) // nolint: errcheck
+
+	path := filepath.Join(directory, "autosave.pgn")
+	game := newTestSessionGame(test, "c4b3\na5b5\nundo\n")
+	// moves can't be undone in timed games
+	game.SetClock(nil)
+	game.SetAutosavePath(path)
+	if err := game.Play(context.Background()); err != io.EOF {
+		test.Fatal(err)
+	}
+
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	savedGames, err := pgn.DecodeGames(string(text))
+	if err != nil || len(savedGames) != 1 {
+		test.Fatal(err)
+	}
+	// the undone move isn't saved
+	if len(savedGames[0].Moves) != 1 {
+		test.Fail()
+	}
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		test.Fatal(err)
+	}
+	// temporary files are removed
+	if len(files) != 1 {
+		test.Fail()
+	}
+}
+
+func TestWriteFileAtomically_withIncorrectDirectory(test *testing.T) {
+	path := filepath.Join(os.DevNull, "autosave.pgn")
+	if err := writeFileAtomically(path, []byte("text")); err == nil {
+		test.Fail()
+	}
+}
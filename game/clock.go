@@ -0,0 +1,192 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// ErrTimeIsOver ...
+var ErrTimeIsOver = errors.New("time is over")
+
+// IncrementMode ...
+type IncrementMode int
+
+// ...
+const (
+	// an increment is added after each move
+	FischerIncrement IncrementMode = iota
+	// a spent time is added after each move, but not more than an increment
+	BronsteinIncrement
+)
+
+// TimeControl ...
+type TimeControl struct {
+	Base time.Duration
+	// a base time is added again after each period;
+	// zero means the whole game is a single period
+	MovesPerPeriod int
+	Increment      time.Duration
+	IncrementMode  IncrementMode
+}
+
+// Clock ...
+//
+// It counts a remaining time of each color. Time is spent by a color,
+// for which the clock is started.
+type Clock struct {
+	timeControl    TimeControl
+	now            terminators.Clock
+	remainingTimes map[models.Color]time.Duration
+	moveCounts     map[models.Color]int
+	runningColor   models.Color
+	isRunning      bool
+	startTime      time.Time
+}
+
+// NewClock ...
+func NewClock(timeControl TimeControl, now terminators.Clock) *Clock {
+	clock := &Clock{timeControl: timeControl, now: now}
+	clock.Reset()
+
+	return clock
+}
+
+// TimeControl ...
+func (clock *Clock) TimeControl() TimeControl {
+	return clock.timeControl
+}
+
+// Reset ...
+//
+// It stops the clock and restores initial times.
+func (clock *Clock) Reset() {
+	clock.remainingTimes = map[models.Color]time.Duration{
+		models.Black: clock.timeControl.Base,
+		models.White: clock.timeControl.Base,
+	}
+	clock.moveCounts = make(map[models.Color]int)
+	clock.isRunning = false
+}
+
+// Restore ...
+//
+// It stops the clock and sets a remaining time and a count of made moves
+// of the color (e.g. of a resumed game).
+func (clock *Clock) Restore(
+	color models.Color,
+	remainingTime time.Duration,
+	moveCount int,
+) {
+	clock.remainingTimes[color] = remainingTime
+	clock.moveCounts[color] = moveCount
+	clock.isRunning = false
+}
+
+// Start ...
+//
+// If the clock is running for another color, a time spent by that color
+// is counted without an increment.
+func (clock *Clock) Start(color models.Color) {
+	if clock.isRunning {
+		clock.remainingTimes[clock.runningColor] -= clock.elapsedTime()
+	}
+
+	clock.runningColor = color
+	clock.isRunning = true
+	clock.startTime = clock.now()
+}
+
+// Stop ...
+//
+// It finishes a move of a running color. It returns ErrTimeIsOver
+// if the color is out of time.
+func (clock *Clock) Stop() error {
+	if !clock.isRunning {
+		return nil
+	}
+
+	color := clock.runningColor
+	elapsedTime := clock.elapsedTime()
+	clock.isRunning = false
+
+	clock.remainingTimes[color] -= elapsedTime
+	if clock.remainingTimes[color] <= 0 {
+		clock.remainingTimes[color] = 0
+		return ErrTimeIsOver
+	}
+
+	increment := clock.timeControl.Increment
+	if clock.timeControl.IncrementMode == BronsteinIncrement &&
+		elapsedTime < increment {
+		increment = elapsedTime
+	}
+	clock.remainingTimes[color] += increment
+
+	clock.moveCounts[color]++
+	movesPerPeriod := clock.timeControl.MovesPerPeriod
+	if movesPerPeriod > 0 && clock.moveCounts[color]%movesPerPeriod == 0 {
+		clock.remainingTimes[color] += clock.timeControl.Base
+	}
+
+	return nil
+}
+
+// RemainingTime ...
+//
+// It takes into account a time of the running clock.
+func (clock *Clock) RemainingTime(color models.Color) time.Duration {
+	remainingTime := clock.remainingTimes[color]
+	if clock.isRunning && clock.runningColor == color {
+		remainingTime -= clock.elapsedTime()
+	}
+	if remainingTime < 0 {
+		remainingTime = 0
+	}
+
+	return remainingTime
+}
+
+// IsTimeOver ...
+func (clock *Clock) IsTimeOver(color models.Color) bool {
+	return clock.RemainingTime(color) == 0
+}
+
+// MovesToGo ...
+//
+// It returns a count of moves of the color till a period end.
+// It returns zero if the whole game is a single period.
+func (clock *Clock) MovesToGo(color models.Color) int {
+	movesPerPeriod := clock.timeControl.MovesPerPeriod
+	if movesPerPeriod == 0 {
+		return 0
+	}
+
+	return movesPerPeriod - clock.moveCounts[color]%movesPerPeriod
+}
+
+// String ...
+//
+// It returns remaining times of both colors.
+func (clock *Clock) String() string {
+	return fmt.Sprintf(
+		"white %s, black %s",
+		encodeDuration(clock.RemainingTime(models.White)),
+		encodeDuration(clock.RemainingTime(models.Black)),
+	)
+}
+
+func (clock *Clock) elapsedTime() time.Duration {
+	return clock.now().Sub(clock.startTime)
+}
+
+func encodeDuration(duration time.Duration) string {
+	duration = duration.Truncate(100 * time.Millisecond)
+	minutes := int(duration / time.Minute)
+	seconds := int(duration % time.Minute / time.Second)
+	tenths := int(duration % time.Second / (100 * time.Millisecond))
+	return fmt.Sprintf("%d:%02d.%d", minutes, seconds, tenths)
+}
@@ -0,0 +1,244 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (clock *fakeClock) now() time.Time {
+	return clock.current
+}
+
+func (clock *fakeClock) advance(duration time.Duration) {
+	clock.current = clock.current.Add(duration)
+}
+
+func TestNewClock(test *testing.T) {
+	timeControl := TimeControl{Base: 5 * time.Minute}
+	clock := NewClock(timeControl, (&fakeClock{}).now)
+
+	if clock.TimeControl() != timeControl {
+		test.Fail()
+	}
+	for _, color := range []models.Color{models.Black, models.White} {
+		if clock.RemainingTime(color) != 5*time.Minute {
+			test.Fail()
+		}
+	}
+}
+
+func TestClockStop(test *testing.T) {
+	type fields struct {
+		timeControl TimeControl
+	}
+	type args struct {
+		elapsedTimes []time.Duration
+	}
+	type data struct {
+		fields            fields
+		args              args
+		wantRemainingTime time.Duration
+		wantErr           error
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{
+				timeControl: TimeControl{Base: time.Minute},
+			},
+			args:              args{[]time.Duration{10 * time.Second}},
+			wantRemainingTime: 50 * time.Second,
+			wantErr:           nil,
+		},
+		{
+			fields: fields{
+				timeControl: TimeControl{
+					Base:          time.Minute,
+					Increment:     2 * time.Second,
+					IncrementMode: FischerIncrement,
+				},
+			},
+			args:              args{[]time.Duration{time.Second, 10 * time.Second}},
+			wantRemainingTime: 53 * time.Second,
+			wantErr:           nil,
+		},
+		{
+			fields: fields{
+				timeControl: TimeControl{
+					Base:          time.Minute,
+					Increment:     2 * time.Second,
+					IncrementMode: BronsteinIncrement,
+				},
+			},
+			args:              args{[]time.Duration{time.Second, 10 * time.Second}},
+			wantRemainingTime: 52 * time.Second,
+			wantErr:           nil,
+		},
+		{
+			fields: fields{
+				timeControl: TimeControl{
+					Base:           time.Minute,
+					MovesPerPeriod: 2,
+				},
+			},
+			args: args{
+				[]time.Duration{10 * time.Second, 10 * time.Second, time.Second},
+			},
+			wantRemainingTime: 99 * time.Second,
+			wantErr:           nil,
+		},
+		{
+			fields: fields{
+				timeControl: TimeControl{
+					Base:      time.Minute,
+					Increment: 2 * time.Second,
+				},
+			},
+			args:              args{[]time.Duration{30 * time.Second, time.Minute}},
+			wantRemainingTime: 0,
+			wantErr:           ErrTimeIsOver,
+		},
+	} {
+		fakeClock := &fakeClock{}
+		clock := NewClock(data.fields.timeControl, fakeClock.now)
+
+		var gotErr error
+		for _, elapsedTime := range data.args.elapsedTimes {
+			clock.Start(models.White)
+			fakeClock.advance(elapsedTime)
+			gotErr = clock.Stop()
+		}
+
+		if clock.RemainingTime(models.White) != data.wantRemainingTime {
+			test.Fail()
+		}
+		if clock.RemainingTime(models.Black) != time.Minute {
+			test.Fail()
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestClockStart(test *testing.T) {
+	fakeClock := &fakeClock{}
+	clock := NewClock(
+		TimeControl{Base: time.Minute, Increment: 2 * time.Second},
+		fakeClock.now,
+	)
+	clock.Start(models.White)
+	fakeClock.advance(10 * time.Second)
+	clock.Start(models.Black)
+	fakeClock.advance(5 * time.Second)
+
+	// a time of the switched color is counted without an increment
+	if clock.RemainingTime(models.White) != 50*time.Second {
+		test.Fail()
+	}
+	if clock.RemainingTime(models.Black) != 55*time.Second {
+		test.Fail()
+	}
+	if clock.IsTimeOver(models.Black) {
+		test.Fail()
+	}
+
+	fakeClock.advance(time.Minute)
+	if !clock.IsTimeOver(models.Black) {
+		test.Fail()
+	}
+}
+
+func TestClockReset(test *testing.T) {
+	fakeClock := &fakeClock{}
+	clock := NewClock(TimeControl{Base: time.Minute}, fakeClock.now)
+	clock.Start(models.White)
+	fakeClock.advance(10 * time.Second)
+	clock.Stop() // nolint: errcheck
+	clock.Start(models.Black)
+	clock.Reset()
+	fakeClock.advance(10 * time.Second)
+
+	for _, color := range []models.Color{models.Black, models.White} {
+		if clock.RemainingTime(color) != time.Minute {
+			test.Fail()
+		}
+	}
+}
+
+func TestClockRestore(test *testing.T) {
+	fakeClock := &fakeClock{}
+	clock := NewClock(
+		TimeControl{Base: time.Minute, MovesPerPeriod: 10},
+		fakeClock.now,
+	)
+	clock.Start(models.White)
+	clock.Restore(models.White, 30*time.Second, 3)
+	fakeClock.advance(10 * time.Second)
+
+	if clock.RemainingTime(models.White) != 30*time.Second {
+		test.Fail()
+	}
+	if clock.MovesToGo(models.White) != 7 {
+		test.Fail()
+	}
+	if clock.RemainingTime(models.Black) != time.Minute {
+		test.Fail()
+	}
+}
+
+func TestClockMovesToGo(test *testing.T) {
+	type fields struct {
+		movesPerPeriod int
+		moveCount      int
+	}
+	type data struct {
+		fields fields
+		want   int
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{movesPerPeriod: 0, moveCount: 5},
+			want:   0,
+		},
+		{
+			fields: fields{movesPerPeriod: 10, moveCount: 0},
+			want:   10,
+		},
+		{
+			fields: fields{movesPerPeriod: 10, moveCount: 13},
+			want:   7,
+		},
+	} {
+		timeControl := TimeControl{
+			Base:           5 * time.Minute,
+			MovesPerPeriod: data.fields.movesPerPeriod,
+		}
+		clock := NewClock(timeControl, (&fakeClock{}).now)
+		clock.moveCounts[models.White] = data.fields.moveCount
+		got := clock.MovesToGo(models.White)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestClockString(test *testing.T) {
+	fakeClock := &fakeClock{}
+	clock := NewClock(TimeControl{Base: 5 * time.Minute}, fakeClock.now)
+	clock.Start(models.Black)
+	fakeClock.advance(61*time.Second + 250*time.Millisecond)
+	got := clock.String()
+
+	if got != "white 5:00.0, black 3:58.7" {
+		test.Fail()
+	}
+}
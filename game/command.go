@@ -0,0 +1,175 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+// ...
+var (
+	ErrQuit        = errors.New("quit")
+	ErrResignation = errors.New("resignation")
+)
+
+// nolint: gochecknoglobals
+var (
+	commandDescriptions = map[string]string{
+		"help":   "show this help message",
+		"quit":   "quit the game",
+		"resign": "resign the game",
+		"claim":  "claim a draw (by the fifty-move rule or a repetition)",
+		"draw":   "offer a draw to the opponent",
+		"flip":   "turn the board over",
+		"fen":    "show the board in FEN",
+		"moves":  "show all the correct moves",
+		"undo":   "take back the last move (with an answer of a searcher; not in timed games)",
+		"redo":   "repeat the undone move (with an answer of a searcher; not in timed games)",
+		"hint":   "suggest a move and mark it on the board",
+		"new":    "start a new game",
+		"save":   "save the game in PGN to the file (by default: -pgnOut)",
+	}
+)
+
+// Command ...
+//
+// It's returned by a player instead of a move as an error
+// in order to be executed by a game.
+type Command struct {
+	Name      string
+	Arguments []string
+}
+
+// ParseCommand ...
+//
+// It returns false if the text isn't a known command.
+func ParseCommand(text string) (command Command, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return Command{}, false
+	}
+
+	if _, ok := commandDescriptions[fields[0]]; !ok {
+		return Command{}, false
+	}
+
+	return Command{Name: fields[0], Arguments: fields[1:]}, true
+}
+
+// Error ...
+func (command Command) Error() string {
+	return "command " + command.Name
+}
+
+func (game *Game) executeCommand(command Command) error {
+	switch command.Name {
+	case "help":
+		game.writeHelp()
+	case "quit":
+		return ErrQuit
+	case "resign":
+		return ErrResignation
+	case "claim":
+		if claim := game.drawClaim(); claim != nil {
+			return claim
+		}
+
+		return errors.New("unable to claim a draw: no reason")
+	case "draw":
+		return game.offerDraw(context.Background(), game.Color().Negative())
+	case "flip":
+		game.flipped = !game.flipped
+	case "fen":
+		text := uci.EncodePieceStorage(game.Storage())
+		fmt.Fprintln(game.writer, text) // nolint: errcheck
+	case "moves":
+		moves, err := san.CorrectMoves(game.Storage(), game.Color())
+		if err != nil {
+			return fmt.Errorf("unable to generate moves: %s", err)
+		}
+
+		texts, err := encodeMoves(game.Storage(), moves, game.moveEncoder)
+		if err != nil {
+			return err // don't wrap
+		}
+
+		fmt.Fprintln(game.writer, strings.Join(texts, " ")) // nolint: errcheck
+	case "undo":
+		// the clock doesn't keep times of undone moves
+		if game.clock != nil {
+			return errors.New("unable to undo: the game is timed")
+		}
+		if !game.history.UndoHumanMove() {
+			return errors.New("unable to undo: no moves")
+		}
+
+		game.resetHint()
+		game.autosave()
+	case "redo":
+		if game.clock != nil {
+			return errors.New("unable to redo: the game is timed")
+		}
+		if !game.history.RedoHumanMove() {
+			return errors.New("unable to redo: no undone moves")
+		}
+
+		game.resetHint()
+		game.autosave()
+	case "hint":
+		if err := game.suggestMove(); err != nil {
+			return err // don't wrap
+		}
+	case "new":
+		game.history.Reset()
+		game.resetHint()
+		game.hasDrawOfferPly = false
+		game.startTime = time.Now()
+		if game.clock != nil {
+			game.clock.Reset()
+		}
+		game.autosave()
+	case "save":
+		path := game.pgnPath
+		if len(command.Arguments) != 0 {
+			path = command.Arguments[0]
+		}
+		if path == "" {
+			return errors.New("unable to save the game: no file")
+		}
+
+		if err := game.SavePGN(path, nil); err != nil {
+			return err // don't wrap
+		}
+
+		fmt.Fprintf(game.writer, "saved to %s\n", path) // nolint: errcheck
+	}
+
+	return nil
+}
+
+func (game *Game) writeHelp() {
+	var names []string
+	for name := range commandDescriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(game.writer, "commands:") // nolint: errcheck
+	for _, name := range names {
+		description := commandDescriptions[name]
+		text := fmt.Sprintf("  %-6s - %s", name, description)
+		fmt.Fprintln(game.writer, text) // nolint: errcheck
+	}
+	for _, text := range []string{
+		"  or a move (e.g. e2e4 or e4)",
+		"  or a promotion (e.g. a4a5q or a5=Q)",
+	} {
+		fmt.Fprintln(game.writer, text) // nolint: errcheck
+	}
+}
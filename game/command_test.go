@@ -0,0 +1,244 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestParseCommand(test *testing.T) {
+	type args struct {
+		text string
+	}
+	type data struct {
+		args        args
+		wantCommand Command
+		wantOk      bool
+	}
+
+	for _, data := range []data{
+		{
+			args:        args{"help"},
+			wantCommand: Command{Name: "help", Arguments: []string{}},
+			wantOk:      true,
+		},
+		{
+			args: args{"  undo 2 "},
+			wantCommand: Command{
+				Name:      "undo",
+				Arguments: []string{"2"},
+			},
+			wantOk: true,
+		},
+		{
+			args:        args{"e2e4"},
+			wantCommand: Command{},
+			wantOk:      false,
+		},
+		{
+			args:        args{""},
+			wantCommand: Command{},
+			wantOk:      false,
+		},
+	} {
+		gotCommand, gotOk := ParseCommand(data.args.text)
+
+		if !reflect.DeepEqual(gotCommand, data.wantCommand) {
+			test.Fail()
+		}
+		if gotOk != data.wantOk {
+			test.Fail()
+		}
+	}
+}
+
+func TestCommandError(test *testing.T) {
+	command := Command{Name: "help"}
+	got := command.Error()
+
+	if got != "command help" {
+		test.Fail()
+	}
+}
+
+func TestGamePlay_withUndoAgainstSearcher(test *testing.T) {
+	type args struct {
+		input string
+	}
+	type data struct {
+		args       args
+		wantOutput string
+		wantColor  models.Color
+	}
+
+	for _, data := range []data{
+		{
+			args:       args{"c4b3\nundo\nfen\n"},
+			wantOutput: "white> " + mateInOne + "\n",
+			wantColor:  models.White,
+		},
+		{
+			args:       args{"c4b3\nundo\nredo\nfen\n"},
+			wantOutput: "white> 1k3/5/1K3/5/1Q3\n",
+			wantColor:  models.White,
+		},
+	} {
+		var output bytes.Buffer
+		reader := bufio.NewReader(strings.NewReader(data.args.input))
+		players := Players{
+			models.White: NewHumanPlayer(reader, ioutil.Discard),
+			models.Black: NewScriptedPlayer(decodeTestMoves(test, "a5b5")),
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, mateInOne),
+		)
+		gotErr := game.Play(context.Background())
+
+		if !strings.Contains(output.String(), data.wantOutput) {
+			test.Fail()
+		}
+		if game.Color() != data.wantColor {
+			test.Fail()
+		}
+		if gotErr != io.EOF {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withCommands(test *testing.T) {
+	type args struct {
+		input string
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantColor  models.Color
+		wantErr    error
+	}
+
+	for _, data := range []data{
+		{
+			args:       args{"help\n"},
+			wantOutput: []string{"commands:\n", "  quit   - quit the game\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"quit\n"},
+			wantOutput: nil,
+			wantColor:  models.White,
+			wantErr:    ErrQuit,
+		},
+		{
+			args:       args{"c4b3\nresign\n"},
+			wantOutput: nil,
+			wantColor:  models.Black,
+			wantErr:    ErrResignation,
+		},
+		{
+			args:       args{"flip\n"},
+			wantOutput: []string{"white> 1.Q..."},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"fen\n"},
+			wantOutput: []string{mateInOne + "\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"moves\n"},
+			wantOutput: []string{"b1b5", "c4c3"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"undo\n"},
+			wantOutput: []string{"error: unable to undo: no moves\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"c4b3\nundo\nfen\n"},
+			wantOutput: []string{"white> " + mateInOne + "\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"redo\n"},
+			wantOutput: []string{"error: unable to redo: no undone moves\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"c4b3\na5b5\nundo\nredo\nfen\n"},
+			wantOutput: []string{"white> 1k3/5/1K3/5/1Q3\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"hint\n"},
+			wantOutput: []string{"hint: "},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+		{
+			args:       args{"c4b3\na5b5\nnew\nfen\n"},
+			wantOutput: []string{mateInOne + "\n"},
+			wantColor:  models.White,
+			wantErr:    io.EOF,
+		},
+	} {
+		var output bytes.Buffer
+		reader := bufio.NewReader(strings.NewReader(data.args.input))
+		human := NewHumanPlayer(reader, ioutil.Discard)
+		players := Players{
+			models.White: human,
+			models.Black: human,
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, mateInOne),
+		)
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if game.Color() != data.wantColor {
+			test.Fail()
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withUndoOnClock(test *testing.T) {
+	for _, input := range []string{"c4b3\nundo\n", "c4b3\nredo\n"} {
+		game := newTestSessionGame(test, input)
+		if err := game.Play(context.Background()); err != io.EOF {
+			test.Fatal(err)
+		}
+
+		if len(game.History().Items()) != 1 {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"context"
+)
+
+// ContextTerminator ...
+//
+// It terminates a search when the context is done.
+type ContextTerminator struct {
+	ctx context.Context
+}
+
+// NewContextTerminator ...
+func NewContextTerminator(ctx context.Context) ContextTerminator {
+	return ContextTerminator{ctx}
+}
+
+// IsSearchTerminated ...
+func (terminator ContextTerminator) IsSearchTerminated(deep int) bool {
+	select {
+	case <-terminator.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
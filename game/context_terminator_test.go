@@ -0,0 +1,20 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextTerminator(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	terminator := NewContextTerminator(ctx)
+
+	if terminator.IsSearchTerminated(1) {
+		test.Fail()
+	}
+
+	cancel()
+	if !terminator.IsSearchTerminated(1) {
+		test.Fail()
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+)
+
+// DeadlineTerminator ...
+//
+// It terminates a search after a hard deadline. After a soft deadline,
+// it doesn't allow to search deeper than the search has reached, so
+// a current iteration of iterative deepening can be finished, but a next one
+// isn't started.
+type DeadlineTerminator struct {
+	clock        terminators.Clock
+	startTime    time.Time
+	softDeadline time.Duration
+	hardDeadline time.Duration
+
+	locker      sync.Mutex
+	reachedDeep int
+}
+
+// NewDeadlineTerminator ...
+//
+// Deadlines are counted from a creation time of the terminator.
+func NewDeadlineTerminator(
+	clock terminators.Clock,
+	softDeadline time.Duration,
+	hardDeadline time.Duration,
+) *DeadlineTerminator {
+	return &DeadlineTerminator{
+		clock:        clock,
+		startTime:    clock(),
+		softDeadline: softDeadline,
+		hardDeadline: hardDeadline,
+	}
+}
+
+// IsSearchTerminated ...
+func (terminator *DeadlineTerminator) IsSearchTerminated(deep int) bool {
+	elapsedTime := terminator.clock().Sub(terminator.startTime)
+	if elapsedTime >= terminator.hardDeadline {
+		return true
+	}
+
+	terminator.locker.Lock()
+	defer terminator.locker.Unlock()
+
+	if elapsedTime < terminator.softDeadline {
+		if deep > terminator.reachedDeep {
+			terminator.reachedDeep = deep
+		}
+
+		return false
+	}
+
+	return deep > terminator.reachedDeep
+}
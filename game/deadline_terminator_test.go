@@ -0,0 +1,56 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineTerminator(test *testing.T) {
+	type step struct {
+		elapsedTime time.Duration
+		deep        int
+		want        bool
+	}
+	type data struct {
+		steps []step
+	}
+
+	for _, data := range []data{
+		{
+			steps: []step{
+				{elapsedTime: 0, deep: 1, want: false},
+				{elapsedTime: time.Second, deep: 2, want: false},
+				{elapsedTime: time.Second, deep: 1, want: false},
+				// after the soft deadline
+				{elapsedTime: 2 * time.Second, deep: 2, want: false},
+				{elapsedTime: 2 * time.Second, deep: 3, want: true},
+				// after the hard deadline
+				{elapsedTime: 4 * time.Second, deep: 1, want: true},
+			},
+		},
+		{
+			steps: []step{
+				{elapsedTime: 0, deep: 5, want: false},
+				{elapsedTime: 4 * time.Second, deep: 1, want: true},
+			},
+		},
+	} {
+		fakeClock := &fakeClock{}
+		terminator := NewDeadlineTerminator(
+			fakeClock.now,
+			2*time.Second,
+			4*time.Second,
+		)
+
+		var elapsedTime time.Duration
+		for _, step := range data.steps {
+			fakeClock.advance(step.elapsedTime - elapsedTime)
+			elapsedTime = step.elapsedTime
+
+			got := terminator.IsSearchTerminated(step.deep)
+			if got != step.want {
+				test.Fail()
+			}
+		}
+	}
+}
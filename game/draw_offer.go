@@ -0,0 +1,117 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// ErrDrawByAgreement ...
+//
+// It's returned by Game.Play(), when a draw offer is accepted.
+var ErrDrawByAgreement = errors.New("agreement")
+
+const (
+	// a searcher offers a draw in endings only, i.e. when a count of pieces
+	// except kings doesn't exceed this value
+	maximalEndingPieceCount = 4
+	// a searcher doesn't repeat an offer more often (in halfmoves)
+	drawOfferInterval = 10
+)
+
+// DrawResponder ...
+//
+// It's an optional interface of a player, which answers draw offers.
+// If a player doesn't implement it, offers to it are declined.
+type DrawResponder interface {
+	// AcceptDraw ...
+	//
+	// The color is a color of the player. The storage can be with any color
+	// to move, so it's passed separately.
+	AcceptDraw(
+		ctx context.Context,
+		storage models.PieceStorage,
+		colorToMove models.Color,
+		color models.Color,
+	) (bool, error)
+}
+
+// it offers a draw to a player of the color and returns ErrDrawByAgreement,
+// if the offer is accepted; the player answers on its own time
+func (game *Game) offerDraw(ctx context.Context, color models.Color) error {
+	player := game.players[color]
+	responder, ok := player.(DrawResponder)
+	if !ok {
+		fmt.Fprintln(game.writer, "draw is declined") // nolint: errcheck
+		return nil
+	}
+
+	if game.clock != nil {
+		var cancel context.CancelFunc
+		var err error
+		ctx, cancel, err = game.startClock(ctx, player, color)
+		if err != nil {
+			return err // don't wrap
+		}
+		defer cancel()
+	}
+
+	isAccepted, err := responder.AcceptDraw(
+		ctx,
+		game.Storage(),
+		game.Color(),
+		color,
+	)
+	if err != nil && game.clock != nil && game.clock.IsTimeOver(color) {
+		fmt.Fprintln(game.writer) // nolint: errcheck
+		// a player, who isn't to move, loses on its next move
+		if color == game.Color() {
+			return ErrTimeIsOver
+		}
+
+		isAccepted, err = false, nil
+	}
+	if err != nil {
+		return err // don't wrap
+	}
+	if !isAccepted {
+		fmt.Fprintln(game.writer, "draw is declined") // nolint: errcheck
+		return nil
+	}
+
+	return ErrDrawByAgreement
+}
+
+// it detects dead-equal endings, in which a searcher offers a draw
+// after its move
+func (game *Game) shouldOfferDraw(
+	move climodels.Move,
+	analysis MoveAnalysis,
+) bool {
+	if analysis.Score != 0 {
+		return false
+	}
+
+	ply := len(game.history.Items())
+	if game.hasDrawOfferPly && ply-game.drawOfferPly < drawOfferInterval {
+		return false
+	}
+
+	return countPieces(move.Apply(game.Storage())) <= maximalEndingPieceCount
+}
+
+// it counts pieces except kings
+func countPieces(storage models.PieceStorage) int {
+	var count int
+	for _, position := range storage.Size().Positions() {
+		if piece, ok := storage.Piece(position); ok &&
+			piece.Kind() != models.King {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	// black has an extra queen
+	lostForWhite = "K4/2k2/4q/5/5"
+	// rooks can't be captured in two halfmoves
+	equalEnding = "k4/3r1/5/5/1R2K"
+)
+
+func TestGamePlay_withDrawOffers(test *testing.T) {
+	type args struct {
+		fen     string
+		players func(reader *bufio.Reader) Players
+		input   string
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantErr    error
+	}
+
+	humans := func(reader *bufio.Reader) Players {
+		human := NewHumanPlayer(reader, ioutil.Discard)
+		return Players{models.White: human, models.Black: human}
+	}
+	againstSearcher := func(contempt float64) func(*bufio.Reader) Players {
+		return func(reader *bufio.Reader) Players {
+			settings := newTestSearchSettings()
+			settings.Contempt = contempt
+
+			return Players{
+				models.White: NewHumanPlayer(reader, ioutil.Discard),
+				models.Black: NewSearcherPlayer(settings),
+			}
+		}
+	}
+	for _, data := range []data{
+		{
+			args: args{
+				fen:     mateInOne,
+				players: humans,
+				input:   "draw\ny\n",
+			},
+			wantOutput: nil,
+			wantErr:    ErrDrawByAgreement,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				players: humans,
+				input:   "draw\nn\n",
+			},
+			wantOutput: []string{"draw is declined\n"},
+			wantErr:    io.EOF,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				players: againstSearcher(0),
+				input:   "draw\n",
+			},
+			wantOutput: nil,
+			wantErr:    ErrDrawByAgreement,
+		},
+		{
+			args: args{
+				fen:     lostForWhite,
+				players: againstSearcher(0),
+				input:   "draw\n",
+			},
+			wantOutput: []string{"draw is declined\n"},
+			wantErr:    io.EOF,
+		},
+		{
+			args: args{
+				// the searcher prefers a draw to a lost queen
+				fen:     lostForWhite,
+				players: againstSearcher(-20),
+				input:   "draw\n",
+			},
+			wantOutput: nil,
+			wantErr:    ErrDrawByAgreement,
+		},
+		{
+			args: args{
+				fen: equalEnding,
+				players: func(reader *bufio.Reader) Players {
+					return Players{
+						models.White: NewSearcherPlayer(newTestSearchSettings()),
+						models.Black: NewHumanPlayer(reader, ioutil.Discard),
+					}
+				},
+				input: "y\n",
+			},
+			wantOutput: []string{" (offers a draw)\n"},
+			wantErr:    ErrDrawByAgreement,
+		},
+	} {
+		var output bytes.Buffer
+		reader := bufio.NewReader(strings.NewReader(data.args.input))
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			data.args.players(reader),
+			decodeTestStorage(test, data.args.fen),
+		)
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withDrawOfferAndClock(test *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("draw\n"))
+	players := Players{
+		models.White: NewHumanPlayer(reader, ioutil.Discard),
+		models.Black: NewSearcherPlayer(newTestSearchSettings()),
+	}
+	game := NewGame(
+		ioutil.Discard,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.SetClock(NewClock(TimeControl{Base: time.Minute}, time.Now))
+	gotErr := game.Play(context.Background())
+
+	// the searcher answers on its own time
+	if game.clock.RemainingTime(models.Black) == time.Minute {
+		test.Fail()
+	}
+	if gotErr != ErrDrawByAgreement {
+		test.Fail()
+	}
+}
+
+func TestCountPieces(test *testing.T) {
+	for fen, want := range map[string]int{
+		"k4/5/5/5/K4": 0,
+		equalEnding:   2,
+		mateInOne:     1,
+	} {
+		if got := countPieces(decodeTestStorage(test, fen)); got != want {
+			test.Fail()
+		}
+	}
+}
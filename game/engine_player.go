@@ -0,0 +1,235 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+// ErrEngineStopped ...
+var ErrEngineStopped = errors.New("engine is stopped")
+
+// EnginePlayer ...
+//
+// It's backed by an external engine, which communicates by the Universal
+// Chess Interface. Moves of the engine are validated as moves of other
+// automatic players.
+type EnginePlayer struct {
+	writer   io.Writer
+	lines    <-chan string
+	settings SearchSettings
+	command  *exec.Cmd
+}
+
+// NewEnginePlayer ...
+//
+// The reader and the writer are connected to an output and an input
+// of the engine correspondingly. A duration from the settings is used
+// as a move time, other settings are ignored.
+func NewEnginePlayer(
+	reader io.Reader,
+	writer io.Writer,
+	settings SearchSettings,
+) *EnginePlayer {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	return &EnginePlayer{writer: writer, lines: lines, settings: settings}
+}
+
+// StartEnginePlayer ...
+//
+// It starts the engine process by the command and initializes the engine
+// (see EnginePlayer.Init()).
+func StartEnginePlayer(
+	ctx context.Context,
+	command *exec.Cmd,
+	settings SearchSettings,
+) (*EnginePlayer, error) {
+	writer, err := command.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the engine input: %s", err)
+	}
+
+	reader, err := command.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the engine output: %s", err)
+	}
+
+	if err := command.Start(); err != nil {
+		return nil, fmt.Errorf("unable to start the engine: %s", err)
+	}
+
+	player := NewEnginePlayer(reader, writer, settings)
+	player.command = command
+	if err := player.Init(ctx); err != nil {
+		player.Close()  // nolint: errcheck, gosec
+		return nil, err // don't wrap
+	}
+
+	return player, nil
+}
+
+// Init ...
+//
+// It performs the "uci" and "isready" handshakes.
+func (player *EnginePlayer) Init(ctx context.Context) error {
+	for _, request := range []struct {
+		command  string
+		response string
+	}{
+		{"uci", "uciok"},
+		{"isready", "readyok"},
+	} {
+		if err := player.writeCommand(request.command); err != nil {
+			return err // don't wrap
+		}
+
+		if _, err := player.readResponse(ctx, request.response); err != nil {
+			return fmt.Errorf("unable to initialize the engine: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// Close ...
+//
+// It sends the "quit" command and waits for the engine process end
+// if the engine was started by StartEnginePlayer().
+func (player *EnginePlayer) Close() error {
+	// the engine can be already stopped, so this error is expected
+	player.writeCommand("quit") // nolint: errcheck, gosec
+
+	if player.command == nil {
+		return nil
+	}
+
+	if err := player.command.Wait(); err != nil {
+		return fmt.Errorf("unable to stop the engine: %s", err)
+	}
+
+	return nil
+}
+
+// Side ...
+func (player *EnginePlayer) Side() climodels.Side {
+	return climodels.Searcher
+}
+
+// NextMove ...
+//
+// Deadlines of the context (see Game.SetTimeManager()) replace a move time
+// from the settings. On the context end, the search is stopped by the "stop"
+// command.
+func (player *EnginePlayer) NextMove(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) (climodels.Move, error) {
+	moveTime := player.settings.Duration
+	if deadline, ok := contextSoftDeadline(ctx); ok {
+		moveTime = time.Until(deadline)
+	} else if deadline, ok := ctx.Deadline(); ok {
+		moveTime = time.Until(deadline)
+	}
+	if moveTime < time.Millisecond {
+		moveTime = time.Millisecond
+	}
+
+	colorText := "w"
+	if color == models.Black {
+		colorText = "b"
+	}
+
+	for _, command := range []string{
+		fmt.Sprintf(
+			"position fen %s %s - - 0 1",
+			uci.EncodePieceStorage(storage),
+			colorText,
+		),
+		fmt.Sprintf("go movetime %d", moveTime/time.Millisecond),
+	} {
+		if err := player.writeCommand(command); err != nil {
+			return climodels.Move{}, err // don't wrap
+		}
+	}
+
+	arguments, err := player.readResponse(ctx, "bestmove")
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("unable to get the move: %s", err)
+	}
+	if len(arguments) == 0 || arguments[0] == "0000" ||
+		arguments[0] == "(none)" {
+		if err := Check(storage, color); err != nil {
+			return climodels.Move{}, err // don't wrap
+		}
+
+		return climodels.Move{}, errors.New("engine has no move")
+	}
+
+	move, err := decodeUCIMove(arguments[0])
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("unable to decode the move: %s", err)
+	}
+
+	move = move.WithDefaultPromotion(storage)
+	if err := CheckMove(storage, color, move); err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	return move, nil
+}
+
+func (player *EnginePlayer) writeCommand(command string) error {
+	if _, err := fmt.Fprintln(player.writer, command); err != nil {
+		return fmt.Errorf("unable to send the command: %s", err)
+	}
+
+	return nil
+}
+
+// it skips other lines of the engine and returns arguments of the response;
+// on the context end, it stops the engine and continues waiting
+func (player *EnginePlayer) readResponse(
+	ctx context.Context,
+	name string,
+) ([]string, error) {
+	done := ctx.Done()
+	for {
+		select {
+		case line, ok := <-player.lines:
+			if !ok {
+				return nil, ErrEngineStopped
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) != 0 && fields[0] == name {
+				return fields[1:], nil
+			}
+		case <-done:
+			if err := player.writeCommand("stop"); err != nil {
+				return nil, err // don't wrap
+			}
+
+			// the "stop" command should be sent once
+			done = nil
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	stubEngineMoveVariable    = "GO_CHESS_CLI_STUB_ENGINE_MOVE"
+	stubEngineWaitingVariable = "GO_CHESS_CLI_STUB_ENGINE_WAITING"
+	stubEngineTestName        = "TestStubEngine"
+	stubEngineWaitingForStop  = "stop"
+	stubEngineWithoutWaiting  = ""
+)
+
+// it isn't a real test; it's a stub engine started by tests
+// as a subprocess
+func TestStubEngine(test *testing.T) {
+	move, ok := os.LookupEnv(stubEngineMoveVariable)
+	if !ok {
+		return
+	}
+
+	waiting := os.Getenv(stubEngineWaitingVariable)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "uci":
+			fmt.Println("id name stub")
+			fmt.Println("uciok")
+		case "isready":
+			fmt.Println("readyok")
+		case "go":
+			fmt.Println("info depth 1")
+			if waiting != stubEngineWaitingForStop {
+				fmt.Println("bestmove", move)
+			}
+		case "stop":
+			fmt.Println("bestmove", move)
+		case "quit":
+			os.Exit(0)
+		}
+	}
+
+	os.Exit(0)
+}
+
+func startTestEnginePlayer(
+	test *testing.T,
+	move string,
+	waiting string,
+) *EnginePlayer {
+	command := exec.Command(os.Args[0], "-test.run="+stubEngineTestName)
+	command.Env = append(
+		os.Environ(),
+		stubEngineMoveVariable+"="+move,
+		stubEngineWaitingVariable+"="+waiting,
+	)
+
+	player, err := StartEnginePlayer(
+		context.Background(),
+		command,
+		newTestSearchSettings(),
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return player
+}
+
+func TestEnginePlayerNextMove(test *testing.T) {
+	type args struct {
+		fen     string
+		move    string
+		waiting string
+		timeout time.Duration
+	}
+	type data struct {
+		args     args
+		wantMove climodels.Move
+		wantErr  bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen:     mateInOne,
+				move:    "b1b5",
+				waiting: stubEngineWithoutWaiting,
+				timeout: time.Second,
+			},
+			wantMove: decodeTestMoves(test, "b1b5")[0],
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				move:    "b1b5",
+				waiting: stubEngineWaitingForStop,
+				timeout: 10 * time.Millisecond,
+			},
+			wantMove: decodeTestMoves(test, "b1b5")[0],
+			wantErr:  false,
+		},
+		{
+			args: args{
+				fen:     "4k/P4/5/5/4K",
+				move:    "a4a5n",
+				waiting: stubEngineWithoutWaiting,
+				timeout: time.Second,
+			},
+			wantMove: climodels.Move{
+				Move:      decodeTestMoves(test, "a4a5")[0].Move,
+				Promotion: models.Knight,
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				move:    "b1b6",
+				waiting: stubEngineWithoutWaiting,
+				timeout: time.Second,
+			},
+			wantMove: climodels.Move{},
+			wantErr:  true,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				move:    "0000",
+				waiting: stubEngineWithoutWaiting,
+				timeout: time.Second,
+			},
+			wantMove: climodels.Move{},
+			wantErr:  true,
+		},
+	} {
+		player := startTestEnginePlayer(test, data.args.move, data.args.waiting)
+		ctx, cancel :=
+			context.WithTimeout(context.Background(), data.args.timeout)
+		gotMove, gotErr := player.NextMove(
+			ctx,
+			decodeTestStorage(test, data.args.fen),
+			models.White,
+		)
+		cancel()
+
+		if gotMove != data.wantMove {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+		if err := player.Close(); err != nil {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withEnginePlayer(test *testing.T) {
+	player := startTestEnginePlayer(test, "b1b5", stubEngineWithoutWaiting)
+	defer player.Close() // nolint: errcheck
+
+	var output bytes.Buffer
+	players := Players{
+		models.White: player,
+		models.Black: NewScriptedPlayer(nil),
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	gotErr := game.Play(context.Background())
+
+	if !strings.Contains(output.String(), "white> (searching) b1b5\n") {
+		test.Fail()
+	}
+	if gotErr != minimax.ErrCheckmate {
+		test.Fail()
+	}
+}
@@ -0,0 +1,360 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// Game ...
+type Game struct {
+	writer          io.Writer
+	storageEncoder  ascii.PieceStorageEncoder
+	moveEncoder     MoveEncoder
+	players         Players
+	history         *History
+	clock           *Clock
+	timeManager     TimeManager
+	searchInfoMode  SearchInfoMode
+	hintSettings    SearchSettings
+	hintMarker      ascii.Marker
+	autoHint        bool
+	hint            climodels.Move
+	hasHint         bool
+	hasDrawOffer    bool
+	drawOfferPly    int
+	hasDrawOfferPly bool
+	interrupts      <-chan os.Signal
+	interruptCount  int
+	startTime       time.Time
+	pgnPath         string
+	autosavePath    string
+	sessionTags     map[string]string
+	moveList        bool
+	flipBoard       bool
+	flipped         bool
+}
+
+// NewGame ...
+//
+// White moves first.
+func NewGame(
+	writer io.Writer,
+	storageEncoder ascii.PieceStorageEncoder,
+	players Players,
+	storage models.PieceStorage,
+) *Game {
+	return &Game{
+		writer:         writer,
+		storageEncoder: storageEncoder,
+		moveEncoder:    EncodeUCIMove,
+		timeManager:    DefaultTimeManager{},
+		hintSettings:   SearchSettings{Deep: hintDeep, Duration: hintDuration},
+		players:        players,
+		history:        NewHistory(storage),
+		startTime:      time.Now(),
+	}
+}
+
+// SetBoardFlipping ...
+//
+// It turns the board toward a color to move before displaying.
+func (game *Game) SetBoardFlipping(flipBoard bool) {
+	game.flipBoard = flipBoard
+}
+
+// SetMoveEncoder ...
+//
+// It sets a notation of displayed moves (by default: pure coordinate one).
+func (game *Game) SetMoveEncoder(moveEncoder MoveEncoder) {
+	game.moveEncoder = moveEncoder
+}
+
+// SetClock ...
+//
+// It limits a thinking time of players (by default: unlimited).
+func (game *Game) SetClock(clock *Clock) {
+	game.clock = clock
+}
+
+// SetTimeManager ...
+//
+// It sets a strategy of allocating a time of the clock for moves
+// of a searcher (by default: DefaultTimeManager).
+func (game *Game) SetTimeManager(timeManager TimeManager) {
+	game.timeManager = timeManager
+}
+
+// SetSearchInfoMode ...
+//
+// It sets a way of displaying a search state of SearcherPlayer
+// (by default: WithoutSearchInfo).
+func (game *Game) SetSearchInfoMode(searchInfoMode SearchInfoMode) {
+	game.searchInfoMode = searchInfoMode
+}
+
+// SetPGNPath ...
+//
+// It sets a default path for the save command.
+func (game *Game) SetPGNPath(path string) {
+	game.pgnPath = path
+}
+
+// History ...
+func (game *Game) History() *History {
+	return game.history
+}
+
+// Storage ...
+func (game *Game) Storage() models.PieceStorage {
+	return game.history.Storage()
+}
+
+// Color ...
+//
+// It returns a color to move.
+func (game *Game) Color() models.Color {
+	return game.history.Color()
+}
+
+// Play ...
+//
+// It returns a state, for which IsWin() or IsDraw() is true, on the game end,
+// ErrQuit or ErrInterrupted on the user request and io.EOF on the end
+// of moves.
+// Errors of an interactive player are displayed and the move is requested
+// again, errors of an automatic one are returned.
+func (game *Game) Play(ctx context.Context) error {
+	for {
+		if game.clock != nil {
+			if game.clock.IsTimeOver(game.Color()) {
+				return ErrTimeIsOver
+			}
+		}
+
+		player := game.players[game.Color()]
+		move, err := game.nextMove(ctx, player)
+		if command, ok := err.(Command); ok {
+			if err = game.executeCommand(command); err == nil {
+				continue
+			}
+		}
+		if err == nil && game.clock != nil {
+			err = game.clock.Stop()
+		}
+		switch {
+		case err == nil:
+		case IsWin(err), IsDraw(err),
+			err == ErrQuit, err == ErrInterrupted, err == io.EOF:
+			return err // don't wrap
+		default:
+			if player.Side() == climodels.Searcher {
+				return err // don't wrap
+			}
+
+			fmt.Fprintf(game.writer, "error: %s\n", err) // nolint: errcheck
+			continue
+		}
+
+		game.history.Push(HistoryItem{
+			Move:    move,
+			Storage: move.Apply(game.Storage()),
+			Side:    player.Side(),
+		})
+		game.resetHint()
+		game.interruptCount = 0
+		game.autosave()
+
+		if game.hasDrawOffer {
+			game.hasDrawOffer = false
+			if err := game.offerDraw(ctx, game.Color()); err != nil {
+				return err // don't wrap
+			}
+		}
+	}
+}
+
+func (game *Game) nextMove(
+	ctx context.Context,
+	player Player,
+) (climodels.Move, error) {
+	if game.autoHint && player.Side() == climodels.Human && !game.hasHint {
+		// a game state, on which a hint is impossible, is reported by the prompt
+		game.suggestMove() // nolint: errcheck, gosec
+	}
+
+	prompt, err := game.writePrompt(player)
+	if err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	if game.clock != nil {
+		// a time of the hint and of the prompt isn't charged to the player
+		var cancel context.CancelFunc
+		ctx, cancel, err = game.startClock(ctx, player, game.Color())
+		if err != nil {
+			return climodels.Move{}, err // don't wrap
+		}
+		defer cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stopWatchingInterrupts := game.watchInterrupts(player, prompt, cancel)
+
+	analysisHolder := &moveAnalysisHolder{}
+	ctx = withMoveAnalysis(ctx, analysisHolder)
+
+	var stopSearchInfo func()
+	// only the built-in searcher reports its state
+	if _, ok := player.(SearcherPlayer); ok &&
+		game.searchInfoMode != WithoutSearchInfo {
+		progress := NewSearchProgress(time.Now)
+		ctx = withSearchProgress(ctx, progress)
+		stopSearchInfo = game.startSearchInfo(progress, prompt)
+	}
+
+	move, err := player.NextMove(ctx, game.Storage(), game.Color())
+	isQuit := stopWatchingInterrupts()
+	if stopSearchInfo != nil {
+		stopSearchInfo()
+	}
+	if isQuit {
+		fmt.Fprintln(game.writer) // nolint: errcheck
+		return climodels.Move{}, ErrInterrupted
+	}
+	if err != nil && game.clock != nil && game.clock.IsTimeOver(game.Color()) {
+		fmt.Fprintln(game.writer) // nolint: errcheck
+		return climodels.Move{}, ErrTimeIsOver
+	}
+	if err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	if player.Side() == climodels.Searcher {
+		text, err := game.moveEncoder(game.Storage(), move)
+		if err != nil {
+			return climodels.Move{}, fmt.Errorf("unable to encode the move: %s", err)
+		}
+
+		if analysisHolder.isSet {
+			analysisText, err := EncodeMoveAnalysis(
+				game.Storage(),
+				game.Color(),
+				move,
+				analysisHolder.analysis,
+				game.moveEncoder,
+			)
+			if err != nil {
+				return climodels.Move{}, err // don't wrap
+			}
+
+			text += " " + analysisText
+
+			if game.shouldOfferDraw(move, analysisHolder.analysis) {
+				game.hasDrawOffer = true
+				game.drawOfferPly = len(game.history.Items())
+				game.hasDrawOfferPly = true
+				text += " (offers a draw)"
+			}
+		}
+
+		fmt.Fprintln(game.writer, text) // nolint: errcheck
+	}
+
+	return move, nil
+}
+
+// it starts the clock for the player of the color and limits the context
+// by a time of the clock: a searcher gets an allocated time, a human
+// is flagged as soon as its time is over
+func (game *Game) startClock(
+	ctx context.Context,
+	player Player,
+	color models.Color,
+) (context.Context, context.CancelFunc, error) {
+	game.clock.Start(color)
+
+	if player.Side() != climodels.Searcher {
+		remainingTime := game.clock.RemainingTime(color)
+		ctx, cancel := context.WithTimeout(ctx, remainingTime)
+		return ctx, cancel, nil
+	}
+
+	deadlines, err := game.allocateTime(color)
+	if err != nil {
+		return nil, nil, err // don't wrap
+	}
+
+	ctx, cancel := withDeadlines(ctx, time.Now(), deadlines)
+	return ctx, cancel, nil
+}
+
+func (game *Game) allocateTime(color models.Color) (Deadlines, error) {
+	moves, err := san.CorrectMoves(game.Storage(), color)
+	if err != nil {
+		return Deadlines{}, fmt.Errorf("unable to generate moves: %s", err)
+	}
+
+	return game.timeManager.AllocateTime(TimeState{
+		RemainingTime: game.clock.RemainingTime(color),
+		Increment:     game.clock.TimeControl().Increment,
+		MovesToGo:     game.clock.MovesToGo(color),
+		MoveNumber:    len(game.history.Items())/2 + 1,
+		MoveCount:     len(moves),
+	}), nil
+}
+
+// it returns the last line of the prompt
+func (game *Game) writePrompt(player Player) (string, error) {
+	storageEncoder := game.storageEncoder
+	if game.flipBoard {
+		storageEncoder = storageEncoder.WithTopColor(game.Color().Negative())
+	}
+	if game.flipped {
+		topColor := storageEncoder.TopColor().Negative()
+		storageEncoder = storageEncoder.WithTopColor(topColor)
+	}
+	storageEncoder = game.markHint(storageEncoder)
+	if game.moveList {
+		lines, err := game.encodeMoveList()
+		if err != nil {
+			return "", err // don't wrap
+		}
+
+		storageEncoder = storageEncoder.WithPanel(lines...)
+	}
+
+	text := storageEncoder.EncodePieceStorage(game.Storage())
+	fmt.Fprintln(game.writer, text) // nolint: errcheck
+
+	if game.clock != nil {
+		fmt.Fprintf(game.writer, "clock: %s\n", game.clock) // nolint: errcheck
+	}
+
+	if err := game.writeHint(); err != nil {
+		return "", err // don't wrap
+	}
+
+	if err := game.checkState(player); err != nil {
+		return "", err // don't wrap
+	}
+
+	var mark string
+	if player.Side() == climodels.Searcher {
+		mark = "(searching) "
+	}
+
+	prompt := fmt.Sprintf("%s> %s", ascii.EncodeColor(game.Color()), mark)
+	fmt.Fprint(game.writer, prompt) // nolint: errcheck
+
+	return prompt, nil
+}
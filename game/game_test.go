@@ -0,0 +1,375 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+const (
+	// white mates in one, e.g. by b1b5
+	mateInOne = "k4/2K2/5/5/1Q3"
+)
+
+func decodeTestStorage(test *testing.T, fen string) models.PieceStorage {
+	storage, err := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return storage
+}
+
+func decodeTestMoves(test *testing.T, texts ...string) []climodels.Move {
+	var moves []climodels.Move
+	for _, text := range texts {
+		move, err := decodeUCIMove(text)
+		if err != nil {
+			test.Fatal(err)
+		}
+
+		moves = append(moves, move)
+	}
+
+	return moves
+}
+
+func newTestStorageEncoder() ascii.PieceStorageEncoder {
+	return ascii.NewPieceStorageEncoder(
+		uci.EncodePiece,
+		".",
+		ascii.Margins{},
+		ascii.WithoutColor,
+		models.Black,
+		1,
+	)
+}
+
+func newTestSearchSettings() SearchSettings {
+	return SearchSettings{
+		Deep:     2,
+		Duration: time.Second,
+	}
+}
+
+func newTestSearchCache() caches.Cache {
+	return caches.NewParallelCache(caches.NewStringHashingCache(
+		1e6,
+		uci.EncodePieceStorage,
+	))
+}
+
+func TestGamePlay(test *testing.T) {
+	type args struct {
+		fen     string
+		players func(input string) Players
+		input   string
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantColor  models.Color
+		wantErr    error
+	}
+
+	humanAgainstSearcher := func(input string) Players {
+		return NewPlayers(
+			NewHumanPlayer(
+				bufio.NewReader(strings.NewReader(input)),
+				ioutil.Discard,
+			),
+			NewSearcherPlayer(newTestSearchSettings()),
+			models.White,
+		)
+	}
+	for _, data := range []data{
+		{
+			args: args{
+				fen:     mateInOne,
+				players: humanAgainstSearcher,
+				input:   "b1b5\n",
+			},
+			wantOutput: []string{"white> "},
+			wantColor:  models.Black,
+			wantErr:    minimax.ErrCheckmate,
+		},
+		{
+			args: args{
+				fen:     mateInOne,
+				players: humanAgainstSearcher,
+				input:   "b1b6\nb2b3\nincorrect\n",
+			},
+			wantOutput: []string{
+				"error: incorrect move: ",
+				"error: incorrect move: ",
+				"error: unable to decode the move: ",
+			},
+			wantColor: models.White,
+			wantErr:   io.EOF,
+		},
+		{
+			args: args{
+				fen: mateInOne,
+				players: func(input string) Players {
+					return Players{
+						models.White: NewSearcherPlayer(newTestSearchSettings()),
+						models.Black: NewSearcherPlayer(newTestSearchSettings()),
+					}
+				},
+			},
+			wantOutput: []string{"white> (searching) "},
+			wantColor:  models.Black,
+			wantErr:    minimax.ErrCheckmate,
+		},
+		{
+			args: args{
+				fen: mateInOne,
+				players: func(input string) Players {
+					return Players{
+						models.White: NewScriptedPlayer(decodeTestMoves(test, "c4b3", "b3c3")),
+						models.Black: NewScriptedPlayer(decodeTestMoves(test, "a5b5")),
+					}
+				},
+			},
+			wantOutput: []string{"c4b3\n", "a5b5\n", "b3c3\n"},
+			wantColor:  models.Black,
+			wantErr:    io.EOF,
+		},
+	} {
+		var output bytes.Buffer
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			data.args.players(data.args.input),
+			decodeTestStorage(test, data.args.fen),
+		)
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if game.Color() != data.wantColor {
+			test.Fail()
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withBoardFlipping(test *testing.T) {
+	type args struct {
+		flipBoard bool
+	}
+	type data struct {
+		args       args
+		wantOutput string
+	}
+
+	for _, data := range []data{
+		{
+			args:       args{false},
+			wantOutput: "white> 5k....",
+		},
+		{
+			args:       args{true},
+			wantOutput: "white> 1.Q...",
+		},
+	} {
+		var output bytes.Buffer
+		reader := bufio.NewReader(strings.NewReader("c4b3\n"))
+		human := NewHumanPlayer(reader, ioutil.Discard)
+		players := Players{
+			models.White: human,
+			models.Black: human,
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, mateInOne),
+		)
+		game.SetBoardFlipping(data.args.flipBoard)
+		gotErr := game.Play(context.Background())
+
+		if !strings.Contains(output.String(), data.wantOutput) {
+			test.Fail()
+		}
+		if gotErr != io.EOF {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withIncorrectAutomaticMove(test *testing.T) {
+	var output bytes.Buffer
+	players := Players{
+		// the white king moves under the attack of the black king
+		models.White: NewScriptedPlayer(decodeTestMoves(test, "c4b4")),
+		models.Black: NewScriptedPlayer(nil),
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	gotErr := game.Play(context.Background())
+
+	if gotErr == nil || gotErr == io.EOF {
+		test.Fail()
+	}
+}
+
+func TestGamePlay_withMoveEncoder(test *testing.T) {
+	var output bytes.Buffer
+	players := Players{
+		models.White: NewScriptedPlayer(decodeTestMoves(test, "c4b3", "b3c3")),
+		models.Black: NewScriptedPlayer(decodeTestMoves(test, "a5b5")),
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.SetMoveEncoder(san.EncodeMove)
+	gotErr := game.Play(context.Background())
+
+	for _, line := range []string{"Kb3\n", "Kb5\n", "Kc3+\n"} {
+		if !strings.Contains(output.String(), line) {
+			test.Fail()
+		}
+	}
+	if gotErr != io.EOF {
+		test.Fail()
+	}
+}
+
+type slowPlayer struct {
+	Player
+
+	clock *fakeClock
+	delay time.Duration
+}
+
+func (player slowPlayer) NextMove(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) (climodels.Move, error) {
+	player.clock.advance(player.delay)
+	return player.Player.NextMove(ctx, storage, color)
+}
+
+func TestGamePlay_withClock(test *testing.T) {
+	type args struct {
+		whiteDelay time.Duration
+		blackDelay time.Duration
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantColor  models.Color
+		wantErr    error
+	}
+
+	for _, data := range []data{
+		{
+			args: args{time.Second, time.Second},
+			wantOutput: []string{
+				"clock: white 0:10.0, black 0:10.0\n",
+				"clock: white 0:09.0, black 0:10.0\n",
+				"clock: white 0:09.0, black 0:09.0\n",
+			},
+			wantColor: models.Black,
+			wantErr:   io.EOF,
+		},
+		{
+			args: args{time.Second, time.Minute},
+			wantOutput: []string{
+				"clock: white 0:09.0, black 0:10.0\n",
+			},
+			wantColor: models.Black,
+			wantErr:   ErrTimeIsOver,
+		},
+	} {
+		fakeClock := &fakeClock{}
+		var output bytes.Buffer
+		players := Players{
+			models.White: slowPlayer{
+				Player: NewScriptedPlayer(decodeTestMoves(test, "c4b3", "b3c3")),
+				clock:  fakeClock,
+				delay:  data.args.whiteDelay,
+			},
+			models.Black: slowPlayer{
+				Player: NewScriptedPlayer(decodeTestMoves(test, "a5b5")),
+				clock:  fakeClock,
+				delay:  data.args.blackDelay,
+			},
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, mateInOne),
+		)
+		game.SetClock(NewClock(TimeControl{Base: 10 * time.Second}, fakeClock.now))
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if game.Color() != data.wantColor {
+			test.Fail()
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withHumanOutOfTime(test *testing.T) {
+	// the input is never ended, so only the clock can finish the game
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeWriter.Close() // nolint: errcheck
+
+	human := NewHumanPlayer(bufio.NewReader(pipeReader), ioutil.Discard)
+	players := Players{
+		models.White: human,
+		models.Black: human,
+	}
+	game := NewGame(
+		ioutil.Discard,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.SetClock(NewClock(TimeControl{Base: 100 * time.Millisecond}, time.Now))
+	gotErr := game.Play(context.Background())
+
+	if game.Color() != models.White {
+		test.Fail()
+	}
+	if gotErr != ErrTimeIsOver {
+		test.Fail()
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+)
+
+const (
+	hintDeep     = 3
+	hintDuration = time.Second
+)
+
+// SetHintSettings ...
+//
+// It sets a search of hints (by default: without a cache, with the deep 3
+// and the duration 1s). A cache of a searcher can be shared with hints,
+// then they warm it for the next search.
+func (game *Game) SetHintSettings(settings SearchSettings) {
+	game.hintSettings = settings
+}
+
+// SetHintMarker ...
+//
+// It sets a highlighting of squares of a hint on the board
+// (by default: squares aren't highlighted).
+func (game *Game) SetHintMarker(marker ascii.Marker) {
+	game.hintMarker = marker
+}
+
+// SetAutoHint ...
+//
+// It turns on suggesting a move before each move of a human.
+func (game *Game) SetAutoHint(autoHint bool) {
+	game.autoHint = autoHint
+}
+
+func (game *Game) suggestMove() error {
+	terminator := terminators.NewGroupTerminator(
+		terminators.NewDeepTerminator(game.hintSettings.Deep),
+		terminators.NewTimeTerminator(time.Now, game.hintSettings.Duration),
+	)
+	move, err := Search(
+		game.hintSettings.Cache,
+		game.Storage(),
+		game.Color(),
+		terminator,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to suggest a move: %s", err)
+	}
+
+	hint := climodels.Move{Move: move.Move}
+	game.hint = hint.WithDefaultPromotion(game.Storage())
+	game.hasHint = true
+
+	return nil
+}
+
+func (game *Game) resetHint() {
+	game.hint, game.hasHint = climodels.Move{}, false
+}
+
+func (game *Game) markHint(
+	storageEncoder ascii.PieceStorageEncoder,
+) ascii.PieceStorageEncoder {
+	if !game.hasHint || game.hintMarker == nil {
+		return storageEncoder
+	}
+
+	return storageEncoder.WithMarks(
+		game.hintMarker,
+		game.hint.Start,
+		game.hint.Finish,
+	)
+}
+
+func (game *Game) writeHint() error {
+	if !game.hasHint {
+		return nil
+	}
+
+	text, err := game.moveEncoder(game.Storage(), game.hint)
+	if err != nil {
+		return fmt.Errorf("unable to encode the move: %s", err)
+	}
+
+	fmt.Fprintf(game.writer, "hint: %s\n", text) // nolint: errcheck
+	return nil
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	// white has the only move a5a4
+	onlyMove = "K4/2k2/4p/5/5"
+)
+
+func markTestSquare(text string) string {
+	return "(" + text + ")"
+}
+
+func TestGamePlay_withHint(test *testing.T) {
+	type args struct {
+		input    string
+		autoHint bool
+	}
+	type data struct {
+		args          args
+		wantOutput    []string
+		wantHintCount int
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				input:    "hint\n",
+				autoHint: false,
+			},
+			wantOutput:    []string{"5(K)....\n4(.).k..\n", "hint: a5a4\n"},
+			wantHintCount: 1,
+		},
+		{
+			args: args{
+				input:    "",
+				autoHint: true,
+			},
+			wantOutput:    []string{"5(K)....\n4(.).k..\n", "hint: a5a4\n"},
+			wantHintCount: 1,
+		},
+		{
+			args: args{
+				input:    "hint\na5a4\n",
+				autoHint: false,
+			},
+			wantOutput:    []string{"hint: a5a4\n", "black> "},
+			wantHintCount: 1,
+		},
+		{
+			args: args{
+				input:    "",
+				autoHint: false,
+			},
+			wantOutput:    []string{"5K....\n"},
+			wantHintCount: 0,
+		},
+	} {
+		var output bytes.Buffer
+		reader := bufio.NewReader(strings.NewReader(data.args.input))
+		human := NewHumanPlayer(reader, ioutil.Discard)
+		players := Players{
+			models.White: human,
+			models.Black: human,
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, onlyMove),
+		)
+		game.SetHintMarker(markTestSquare)
+		game.SetAutoHint(data.args.autoHint)
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		gotHintCount := strings.Count(output.String(), "hint: ")
+		if gotHintCount != data.wantHintCount {
+			test.Fail()
+		}
+		// squares are marked only while the hint is actual
+		if strings.Count(output.String(), "(") != 2*data.wantHintCount {
+			test.Fail()
+		}
+		if gotErr != io.EOF {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withHintCache(test *testing.T) {
+	var output bytes.Buffer
+	reader := bufio.NewReader(strings.NewReader("hint\n"))
+	human := NewHumanPlayer(reader, ioutil.Discard)
+	players := Players{
+		models.White: human,
+		models.Black: human,
+	}
+	storage := decodeTestStorage(test, onlyMove)
+	game := NewGame(&output, newTestStorageEncoder(), players, storage)
+	cache := newTestSearchCache()
+	game.SetHintSettings(SearchSettings{
+		Cache:    cache,
+		Deep:     2,
+		Duration: time.Second,
+	})
+	gotErr := game.Play(context.Background())
+
+	// a searcher caches positions after a root one
+	nextStorage := decodeTestMoves(test, "a5a4")[0].Apply(storage)
+	if _, ok := cache.Get(nextStorage, models.Black); !ok {
+		test.Fail()
+	}
+	if !strings.Contains(output.String(), "hint: ") {
+		test.Fail()
+	}
+	if gotErr != io.EOF {
+		test.Fail()
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// HistoryItem ...
+type HistoryItem struct {
+	Move climodels.Move
+	// a storage after the move
+	Storage models.PieceStorage
+	// a side of a player, who made the move
+	Side climodels.Side
+}
+
+// History ...
+//
+// It stores applied moves and moves, which were undone and can be redone.
+// White moves first.
+type History struct {
+	initialStorage models.PieceStorage
+	items          []HistoryItem
+	appliedCount   int
+}
+
+// NewHistory ...
+func NewHistory(initialStorage models.PieceStorage) *History {
+	return &History{initialStorage: initialStorage}
+}
+
+// InitialStorage ...
+func (history *History) InitialStorage() models.PieceStorage {
+	return history.initialStorage
+}
+
+// Storage ...
+//
+// It returns a storage after the last applied move.
+func (history *History) Storage() models.PieceStorage {
+	if history.appliedCount == 0 {
+		return history.initialStorage
+	}
+
+	return history.items[history.appliedCount-1].Storage
+}
+
+// Color ...
+//
+// It returns a color to move.
+func (history *History) Color() models.Color {
+	if history.appliedCount%2 == 0 {
+		return models.White
+	}
+
+	return models.Black
+}
+
+// Items ...
+//
+// It returns applied moves only.
+func (history *History) Items() []HistoryItem {
+	return history.items[:history.appliedCount]
+}
+
+// Push ...
+//
+// It discards moves, which can be redone.
+func (history *History) Push(item HistoryItem) {
+	history.items = append(history.items[:history.appliedCount], item)
+	history.appliedCount++
+}
+
+// Undo ...
+//
+// It returns the undone move.
+func (history *History) Undo() (item HistoryItem, ok bool) {
+	if history.appliedCount == 0 {
+		return HistoryItem{}, false
+	}
+
+	history.appliedCount--
+	return history.items[history.appliedCount], true
+}
+
+// UndoHumanMove ...
+//
+// It undoes moves till a move of a human including it, so after that
+// the human moves again.
+func (history *History) UndoHumanMove() (ok bool) {
+	initialAppliedCount := history.appliedCount
+	for {
+		item, ok := history.Undo()
+		if !ok {
+			return history.appliedCount != initialAppliedCount
+		}
+
+		if item.Side == climodels.Human {
+			return true
+		}
+	}
+}
+
+// Redo ...
+//
+// It returns the redone move.
+func (history *History) Redo() (item HistoryItem, ok bool) {
+	if history.appliedCount == len(history.items) {
+		return HistoryItem{}, false
+	}
+
+	history.appliedCount++
+	return history.items[history.appliedCount-1], true
+}
+
+// RedoHumanMove ...
+//
+// It redoes a move and following moves of a searcher, so after that
+// a human moves again.
+func (history *History) RedoHumanMove() (ok bool) {
+	if _, ok := history.Redo(); !ok {
+		return false
+	}
+
+	for history.appliedCount < len(history.items) {
+		if history.items[history.appliedCount].Side != climodels.Searcher {
+			break
+		}
+
+		history.appliedCount++
+	}
+
+	return true
+}
+
+// Reset ...
+//
+// It discards all the moves.
+func (history *History) Reset() {
+	history.items = nil
+	history.appliedCount = 0
+}
@@ -0,0 +1,287 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func newTestHistory(
+	test *testing.T,
+	sides []climodels.Side,
+	texts ...string,
+) *History {
+	history := NewHistory(decodeTestStorage(test, mateInOne))
+	for index, move := range decodeTestMoves(test, texts...) {
+		history.Push(HistoryItem{
+			Move:    move,
+			Storage: move.Apply(history.Storage()),
+			Side:    sides[index],
+		})
+	}
+
+	return history
+}
+
+func TestNewHistory(test *testing.T) {
+	storage := decodeTestStorage(test, mateInOne)
+	history := NewHistory(storage)
+
+	if !reflect.DeepEqual(history.InitialStorage(), storage) {
+		test.Fail()
+	}
+	if !reflect.DeepEqual(history.Storage(), storage) {
+		test.Fail()
+	}
+	if history.Color() != models.White {
+		test.Fail()
+	}
+	if len(history.Items()) != 0 {
+		test.Fail()
+	}
+}
+
+func TestHistoryPush(test *testing.T) {
+	history := newTestHistory(
+		test,
+		[]climodels.Side{climodels.Human, climodels.Searcher},
+		"c4b3",
+		"a5b5",
+	)
+	history.Undo()
+
+	move := decodeTestMoves(test, "a5a4")[0]
+	storage := move.Apply(history.Storage())
+	history.Push(HistoryItem{
+		Move:    move,
+		Storage: storage,
+		Side:    climodels.Searcher,
+	})
+
+	if len(history.Items()) != 2 || history.Items()[1].Move != move {
+		test.Fail()
+	}
+	if !reflect.DeepEqual(history.Storage(), storage) {
+		test.Fail()
+	}
+	if history.Color() != models.White {
+		test.Fail()
+	}
+	if _, ok := history.Redo(); ok {
+		test.Fail()
+	}
+}
+
+func TestHistoryUndo(test *testing.T) {
+	history := newTestHistory(
+		test,
+		[]climodels.Side{climodels.Human},
+		"c4b3",
+	)
+
+	gotItem, gotOk := history.Undo()
+	if gotItem.Move != decodeTestMoves(test, "c4b3")[0] {
+		test.Fail()
+	}
+	if !gotOk {
+		test.Fail()
+	}
+	if !reflect.DeepEqual(history.Storage(), history.InitialStorage()) {
+		test.Fail()
+	}
+	if history.Color() != models.White {
+		test.Fail()
+	}
+
+	if _, ok := history.Undo(); ok {
+		test.Fail()
+	}
+}
+
+func TestHistoryUndoHumanMove(test *testing.T) {
+	type fields struct {
+		sides []climodels.Side
+		moves []string
+	}
+	type data struct {
+		fields    fields
+		wantCount int
+		wantOk    bool
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{
+				sides: []climodels.Side{climodels.Human, climodels.Searcher},
+				moves: []string{"c4b3", "a5b5"},
+			},
+			wantCount: 0,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides: []climodels.Side{
+					climodels.Searcher,
+					climodels.Human,
+					climodels.Searcher,
+				},
+				moves: []string{"c4b3", "a5b5", "b3c3"},
+			},
+			wantCount: 1,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides: []climodels.Side{climodels.Human, climodels.Human},
+				moves: []string{"c4b3", "a5b5"},
+			},
+			wantCount: 1,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides: []climodels.Side{climodels.Searcher},
+				moves: []string{"c4b3"},
+			},
+			wantCount: 0,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides: nil,
+				moves: nil,
+			},
+			wantCount: 0,
+			wantOk:    false,
+		},
+	} {
+		history := newTestHistory(test, data.fields.sides, data.fields.moves...)
+		gotOk := history.UndoHumanMove()
+
+		if len(history.Items()) != data.wantCount {
+			test.Fail()
+		}
+		if gotOk != data.wantOk {
+			test.Fail()
+		}
+	}
+}
+
+func TestHistoryRedo(test *testing.T) {
+	history := newTestHistory(
+		test,
+		[]climodels.Side{climodels.Human},
+		"c4b3",
+	)
+	storage := history.Storage()
+	history.Undo()
+
+	gotItem, gotOk := history.Redo()
+	if gotItem.Move != decodeTestMoves(test, "c4b3")[0] {
+		test.Fail()
+	}
+	if !gotOk {
+		test.Fail()
+	}
+	if !reflect.DeepEqual(history.Storage(), storage) {
+		test.Fail()
+	}
+	if history.Color() != models.Black {
+		test.Fail()
+	}
+
+	if _, ok := history.Redo(); ok {
+		test.Fail()
+	}
+}
+
+func TestHistoryRedoHumanMove(test *testing.T) {
+	type fields struct {
+		sides     []climodels.Side
+		moves     []string
+		undoCount int
+	}
+	type data struct {
+		fields    fields
+		wantCount int
+		wantOk    bool
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{
+				sides:     []climodels.Side{climodels.Human, climodels.Searcher},
+				moves:     []string{"c4b3", "a5b5"},
+				undoCount: 2,
+			},
+			wantCount: 2,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides: []climodels.Side{
+					climodels.Searcher,
+					climodels.Human,
+					climodels.Searcher,
+				},
+				moves:     []string{"c4b3", "a5b5", "b3c3"},
+				undoCount: 3,
+			},
+			wantCount: 1,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides:     []climodels.Side{climodels.Human, climodels.Human},
+				moves:     []string{"c4b3", "a5b5"},
+				undoCount: 2,
+			},
+			wantCount: 1,
+			wantOk:    true,
+		},
+		{
+			fields: fields{
+				sides:     []climodels.Side{climodels.Human},
+				moves:     []string{"c4b3"},
+				undoCount: 0,
+			},
+			wantCount: 1,
+			wantOk:    false,
+		},
+	} {
+		history := newTestHistory(test, data.fields.sides, data.fields.moves...)
+		for i := 0; i < data.fields.undoCount; i++ {
+			history.Undo()
+		}
+
+		gotOk := history.RedoHumanMove()
+
+		if len(history.Items()) != data.wantCount {
+			test.Fail()
+		}
+		if gotOk != data.wantOk {
+			test.Fail()
+		}
+	}
+}
+
+func TestHistoryReset(test *testing.T) {
+	history := newTestHistory(
+		test,
+		[]climodels.Side{climodels.Human},
+		"c4b3",
+	)
+	history.Reset()
+
+	if !reflect.DeepEqual(history.Storage(), history.InitialStorage()) {
+		test.Fail()
+	}
+	if len(history.Items()) != 0 {
+		test.Fail()
+	}
+	if _, ok := history.Redo(); ok {
+		test.Fail()
+	}
+}
@@ -0,0 +1,174 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// HumanPlayer ...
+type HumanPlayer struct {
+	reader *lineReader
+	writer io.Writer
+}
+
+// NewHumanPlayer ...
+//
+// The writer is used to ask for a kind of a promoted piece.
+func NewHumanPlayer(reader *bufio.Reader, writer io.Writer) HumanPlayer {
+	return HumanPlayer{newLineReader(reader), writer}
+}
+
+// Side ...
+func (player HumanPlayer) Side() climodels.Side {
+	return climodels.Human
+}
+
+// NextMove ...
+//
+// It returns io.EOF on the input end and a Command on a known command.
+// If a promotion is given without a chosen kind, it's asked separately.
+// On the context end, it returns the context error, and the line typed
+// after that is read by a next call.
+func (player HumanPlayer) NextMove(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) (climodels.Move, error) {
+	text, err := player.readLine(ctx)
+	if err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	if command, ok := ParseCommand(text); ok {
+		return climodels.Move{}, command
+	}
+
+	move, err := DecodeMove(storage, color, text)
+	if err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	if err := CheckMove(storage, color, move); err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	isPromotion := climodels.IsPromotion(storage, move.Move)
+	if move.Promotion == models.King && isPromotion {
+		move.Promotion, err = player.readPromotion(ctx)
+		if err != nil {
+			return climodels.Move{}, err // don't wrap
+		}
+	}
+
+	return move, nil
+}
+
+// Ask ...
+//
+// It writes the question and reads an answer without a line end.
+func (player HumanPlayer) Ask(
+	ctx context.Context,
+	question string,
+) (string, error) {
+	fmt.Fprint(player.writer, question) // nolint: errcheck
+	return player.readLine(ctx)
+}
+
+// AcceptDraw ...
+//
+// It asks a human and accepts a draw on an answer starting with "y".
+func (player HumanPlayer) AcceptDraw(
+	ctx context.Context,
+	storage models.PieceStorage,
+	colorToMove models.Color,
+	color models.Color,
+) (bool, error) {
+	question := fmt.Sprintf(
+		"%s, a draw is offered, accept it? (y/n)> ",
+		ascii.EncodeColor(color),
+	)
+	answer, err := player.Ask(ctx, question)
+	if err != nil {
+		return false, err // don't wrap
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return strings.HasPrefix(answer, "y"), nil
+}
+
+func (player HumanPlayer) readLine(ctx context.Context) (string, error) {
+	text, err := player.reader.readLine(ctx)
+	switch {
+	case err == io.EOF && text == "", err != nil && err == ctx.Err():
+		return "", err // don't wrap
+	case err != nil && err != io.EOF:
+		return "", fmt.Errorf("unable to read the move: %s", err)
+	}
+
+	return strings.TrimSuffix(text, "\n"), nil
+}
+
+func (player HumanPlayer) readPromotion(
+	ctx context.Context,
+) (models.Kind, error) {
+	text, err := player.Ask(ctx, "promotion (q, r, b, n; by default: q)> ")
+	if err != nil {
+		return 0, err // don't wrap
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return climodels.DefaultPromotion, nil
+	}
+
+	kind, err := san.DecodeKind(strings.ToUpper(text))
+	if err != nil || !climodels.IsPromotionKind(kind) {
+		return 0, fmt.Errorf("incorrect promotion: %s", text)
+	}
+
+	return kind, nil
+}
+
+// CheckMove ...
+//
+// It checks that the move is correct for the color, including a chosen kind
+// of a promotion and a check to an own king after the move.
+func CheckMove(
+	storage models.PieceStorage,
+	color models.Color,
+	move climodels.Move,
+) error {
+	if err := storage.CheckMove(move.Move); err != nil {
+		return fmt.Errorf("incorrect move: %s", err)
+	}
+
+	if piece, _ := storage.Piece(move.Start); piece.Color() != color {
+		return errors.New("incorrect move: opponent piece")
+	}
+
+	if move.Promotion != models.King {
+		if !climodels.IsPromotionKind(move.Promotion) {
+			return errors.New("incorrect move: incorrect promotion")
+		}
+		if !climodels.IsPromotion(storage, move.Move) {
+			return errors.New("incorrect move: unexpected promotion")
+		}
+	}
+
+	nextStorage := move.Apply(storage)
+	nextColor := color.Negative()
+	if err := Check(nextStorage, nextColor); err == models.ErrKingCapture {
+		return errors.New("incorrect move: check")
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestHumanPlayerSide(test *testing.T) {
+	got := NewHumanPlayer(nil, nil).Side()
+
+	if got != climodels.Human {
+		test.Fail()
+	}
+}
+
+func TestHumanPlayerNextMove(test *testing.T) {
+	type args struct {
+		input string
+		fen   string
+	}
+	type data struct {
+		args          args
+		wantMove      models.Move
+		wantPromotion models.Kind
+		wantErr       bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				input: "b1b5\n",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			// without a line break at the input end
+			args: args{
+				input: "b1b5",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				input: "Qb5#\n",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				input: "a4a5\nn\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 0, Rank: 4},
+			},
+			wantPromotion: models.Knight,
+			wantErr:       false,
+		},
+		{
+			args: args{
+				input: "a5\n\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 0, Rank: 4},
+			},
+			wantPromotion: models.Queen,
+			wantErr:       false,
+		},
+		{
+			args: args{
+				input: "axb5=R\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantPromotion: models.Rook,
+			wantErr:       false,
+		},
+		{
+			args: args{
+				input: "a4a5\nk\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args: args{
+				input: "a4a5\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args: args{
+				input: "e1e2q\n",
+				fen:   promotion,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args: args{
+				input: "incorrect\n",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args: args{
+				input: "a5a4\n",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			// the white king moves under the attack of the black king
+			args: args{
+				input: "c4b4\n",
+				fen:   mateInOne,
+			},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+	} {
+		reader := bufio.NewReader(strings.NewReader(data.args.input))
+		player := NewHumanPlayer(reader, ioutil.Discard)
+		gotMove, gotErr := player.NextMove(
+			context.Background(),
+			decodeTestStorage(test, data.args.fen),
+			models.White,
+		)
+
+		if gotMove.Move != data.wantMove {
+			test.Fail()
+		}
+		if gotMove.Promotion != data.wantPromotion {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestHumanPlayerNextMove_withInputEnd(test *testing.T) {
+	reader := bufio.NewReader(strings.NewReader(""))
+	player := NewHumanPlayer(reader, ioutil.Discard)
+	_, gotErr := player.NextMove(
+		context.Background(),
+		decodeTestStorage(test, mateInOne),
+		models.White,
+	)
+
+	if gotErr != io.EOF {
+		test.Fail()
+	}
+}
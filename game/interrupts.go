@@ -0,0 +1,78 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+)
+
+// ErrInterrupted ...
+//
+// It's returned by Game.Play() on a second interrupt in a row.
+var ErrInterrupted = errors.New("interrupted")
+
+// SetInterrupts ...
+//
+// It sets a source of interrupts (e.g. of SIGINT; by default: without it).
+// The first interrupt stops a search of a computer, which then plays
+// the best move found so far. A second interrupt in a row (i.e. without
+// a made move between them) quits the game.
+func (game *Game) SetInterrupts(interrupts <-chan os.Signal) {
+	game.interrupts = interrupts
+}
+
+// it watches interrupts during a move of the player and cancels the move
+// by the function; it returns a function to stop watching, which reports
+// whether the game should be quit
+func (game *Game) watchInterrupts(
+	player Player,
+	prompt string,
+	cancel context.CancelFunc,
+) (stop func() (isQuit bool)) {
+	if game.interrupts == nil {
+		return func() bool { return false }
+	}
+
+	var isQuit bool
+	stopping, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-game.interrupts:
+				game.interruptCount++
+				if game.interruptCount > 1 {
+					isQuit = true
+					cancel()
+
+					return
+				}
+
+				if player.Side() == climodels.Searcher {
+					cancel()
+					continue
+				}
+
+				// a move of a human isn't cancelled, so only a way to quit is explained
+				fmt.Fprintf( // nolint: errcheck
+					game.writer,
+					"\ninterrupt again to quit\n%s",
+					prompt,
+				)
+			case <-stopping:
+				return
+			}
+		}
+	}()
+
+	return func() bool {
+		close(stopping)
+		<-done
+
+		return isQuit
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestGamePlay_withInterrupts(test *testing.T) {
+	// the input is never ended, so only the interrupts can quit the game
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeWriter.Close() // nolint: errcheck
+
+	var output bytes.Buffer
+	human := NewHumanPlayer(bufio.NewReader(pipeReader), ioutil.Discard)
+	players := Players{
+		models.White: human,
+		models.Black: human,
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+
+	interrupts := make(chan os.Signal, 2)
+	interrupts <- os.Interrupt
+	interrupts <- os.Interrupt
+	game.SetInterrupts(interrupts)
+
+	gotErr := game.Play(context.Background())
+
+	if !strings.Contains(output.String(), "interrupt again to quit") {
+		test.Fail()
+	}
+	if gotErr != ErrInterrupted {
+		test.Fail()
+	}
+}
+
+func TestGamePlay_withInterruptedSearch(test *testing.T) {
+	// the zero delay stops the search before its first iteration
+	for _, delay := range []time.Duration{0, 100 * time.Millisecond} {
+		reader := bufio.NewReader(strings.NewReader(""))
+		players := Players{
+			// the search is stopped only by the interrupt
+			models.White: NewSearcherPlayer(SearchSettings{
+				Deep:     unlimitedTestDeep,
+				Duration: time.Hour,
+			}),
+			models.Black: NewHumanPlayer(reader, ioutil.Discard),
+		}
+		game := NewGame(
+			ioutil.Discard,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, "rnbqk/ppppp/5/PPPPP/RNBQK"),
+		)
+
+		interrupts := make(chan os.Signal, 1)
+		game.SetInterrupts(interrupts)
+		go func(delay time.Duration) {
+			time.Sleep(delay)
+			interrupts <- os.Interrupt
+		}(delay)
+
+		gotErr := game.Play(context.Background())
+
+		items := game.History().Items()
+		if len(items) != 1 {
+			test.FailNow()
+		}
+		storage := game.History().InitialStorage()
+		if err := CheckMove(storage, models.White, items[0].Move); err != nil {
+			test.Fail()
+		}
+		if gotErr != io.EOF {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"bufio"
+	"context"
+)
+
+type lineResult struct {
+	text string
+	err  error
+}
+
+// it reads lines in background, so a reading can be abandoned on the context
+// end without losing a line: the line is returned by a next reading
+type lineReader struct {
+	reader  *bufio.Reader
+	results chan lineResult
+	pending bool
+}
+
+func newLineReader(reader *bufio.Reader) *lineReader {
+	return &lineReader{
+		reader:  reader,
+		results: make(chan lineResult, 1),
+	}
+}
+
+// it returns the context error on the context end
+func (reader *lineReader) readLine(ctx context.Context) (string, error) {
+	if !reader.pending {
+		reader.pending = true
+		go func() {
+			text, err := reader.reader.ReadString('\n')
+			reader.results <- lineResult{text, err}
+		}()
+	}
+
+	select {
+	case result := <-reader.results:
+		reader.pending = false
+		return result.text, result.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
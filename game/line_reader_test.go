@@ -0,0 +1,31 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"testing"
+)
+
+func TestLineReaderReadLine(test *testing.T) {
+	pipeReader, pipeWriter := io.Pipe()
+	reader := newLineReader(bufio.NewReader(pipeReader))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the reading is abandoned, but the line isn't lost
+	if _, err := reader.readLine(ctx); err != context.Canceled {
+		test.Fail()
+	}
+
+	go pipeWriter.Write([]byte("e2e4\n")) // nolint: errcheck
+	gotText, gotErr := reader.readLine(context.Background())
+
+	if gotText != "e2e4\n" {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
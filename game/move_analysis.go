@@ -0,0 +1,151 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	// the material evaluator weighs a king far more than all other pieces
+	// together, so such scores mean a checkmate
+	mateScore = 1e5
+	// the continuation is restored from a cache, so it shouldn't be long
+	maximalContinuationLength = 10
+)
+
+// MoveAnalysis ...
+//
+// It explains a move of a searcher by its score (in pawns, from the point
+// of view of a moving color) and by moves expected after it.
+type MoveAnalysis struct {
+	Score        float64
+	Continuation []climodels.Move
+}
+
+type moveAnalysisHolder struct {
+	analysis MoveAnalysis
+	isSet    bool
+}
+
+type moveAnalysisKey struct{}
+
+func withMoveAnalysis(
+	ctx context.Context,
+	holder *moveAnalysisHolder,
+) context.Context {
+	return context.WithValue(ctx, moveAnalysisKey{}, holder)
+}
+
+func contextMoveAnalysis(ctx context.Context) (*moveAnalysisHolder, bool) {
+	holder, ok := ctx.Value(moveAnalysisKey{}).(*moveAnalysisHolder)
+	return holder, ok
+}
+
+// it restores the principal variation from a transposition table
+func expectedContinuation(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	move climodels.Move,
+) []climodels.Move {
+	if cache == nil {
+		return nil
+	}
+
+	var continuation []climodels.Move
+	storage, color = move.Apply(storage), color.Negative()
+	for len(continuation) < maximalContinuationLength {
+		failedMove, ok := cache.Get(storage, color)
+		if !ok {
+			break
+		}
+
+		nextMove := climodels.Move{Move: failedMove.Move.Move}
+		nextMove = nextMove.WithDefaultPromotion(storage)
+		// a cache can contain a move of another position with a same hash
+		if err := CheckMove(storage, color, nextMove); err != nil {
+			break
+		}
+
+		continuation = append(continuation, nextMove)
+		storage, color = nextMove.Apply(storage), color.Negative()
+	}
+
+	return continuation
+}
+
+// it applies the moves one by one, so each of them is encoded
+// in its own position
+func encodeLine(
+	storage models.PieceStorage,
+	moves []climodels.Move,
+	moveEncoder MoveEncoder,
+) ([]string, models.PieceStorage, error) {
+	var texts []string
+	for _, move := range moves {
+		text, err := moveEncoder(storage, move)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to encode the move: %s", err)
+		}
+
+		texts = append(texts, text)
+		storage = move.Apply(storage)
+	}
+
+	return texts, storage, nil
+}
+
+// EncodeMoveAnalysis ...
+//
+// It returns a text like "(score +0.50, expected e7e5 g1f3)"
+// or "(mate in 2, expected a5a4 b1b4)".
+func EncodeMoveAnalysis(
+	storage models.PieceStorage,
+	color models.Color,
+	move climodels.Move,
+	analysis MoveAnalysis,
+	moveEncoder MoveEncoder,
+) (string, error) {
+	line := append([]climodels.Move{move}, analysis.Continuation...)
+	texts, finalStorage, err := encodeLine(storage, line, moveEncoder)
+	if err != nil {
+		return "", err // don't wrap
+	}
+
+	score := analysis.Score
+	if score == 0 {
+		score = 0 // replace the negative zero
+	}
+
+	scoreText := fmt.Sprintf("score %+.2f", score)
+	if math.Abs(score) >= mateScore {
+		prefix := "mate"
+		if score < 0 {
+			prefix = "mated"
+		}
+
+		// a count of moves is known only if the line leads to a checkmate
+		scoreText = prefix
+		finalColor := color
+		if len(line)%2 == 1 {
+			finalColor = color.Negative()
+		}
+		if Check(finalStorage, finalColor) == minimax.ErrCheckmate {
+			scoreText += fmt.Sprintf(" in %d", (len(line)+1)/2)
+		}
+	}
+
+	parts := []string{scoreText}
+	if len(texts) > 1 {
+		parts = append(parts, "expected "+strings.Join(texts[1:], " "))
+	}
+
+	return "(" + strings.Join(parts, ", ") + ")", nil
+}
@@ -0,0 +1,183 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	moves "github.com/thewizardplusplus/go-chess-minimax/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestExpectedContinuation(test *testing.T) {
+	type args struct {
+		cache func() caches.Cache
+		move  climodels.Move
+	}
+	type data struct {
+		args args
+		want []climodels.Move
+	}
+
+	storage := decodeTestStorage(test, mateInOne)
+	// white: Kc4-b3, black: Ka5-b5, white: Kb3-c3
+	line := decodeTestMoves(test, "c4b3", "a5b5", "b3c3")
+	makeCache := func(moves ...climodels.Move) func() caches.Cache {
+		return func() caches.Cache {
+			cache := newTestSearchCache()
+			currentStorage, color := line[0].Apply(storage), models.Black
+			for _, move := range moves {
+				cache.Set(currentStorage, color, makeTestFailedMove(move))
+				currentStorage, color = move.Apply(currentStorage), color.Negative()
+			}
+
+			return cache
+		}
+	}
+	for _, data := range []data{
+		{
+			args: args{
+				cache: func() caches.Cache { return nil },
+				move:  line[0],
+			},
+			want: nil,
+		},
+		{
+			args: args{
+				cache: makeCache(line[1:]...),
+				move:  line[0],
+			},
+			want: line[1:],
+		},
+		{
+			args: args{
+				// the white king can't move under the attack of the black one
+				cache: makeCache(line[1], decodeTestMoves(test, "b3b4")[0]),
+				move:  line[0],
+			},
+			want: line[1:2],
+		},
+	} {
+		got := expectedContinuation(
+			data.args.cache(),
+			storage,
+			models.White,
+			data.args.move,
+		)
+
+		if !reflect.DeepEqual(got, data.want) {
+			test.Fail()
+		}
+	}
+}
+
+func makeTestFailedMove(move climodels.Move) moves.FailedMove {
+	return moves.FailedMove{Move: moves.ScoredMove{Move: move.Move}}
+}
+
+func TestEncodeMoveAnalysis(test *testing.T) {
+	type args struct {
+		move        climodels.Move
+		analysis    MoveAnalysis
+		moveEncoder MoveEncoder
+	}
+	type data struct {
+		args    args
+		want    string
+		wantErr bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				move:        decodeTestMoves(test, "c4b3")[0],
+				analysis:    MoveAnalysis{Score: 9},
+				moveEncoder: EncodeUCIMove,
+			},
+			want:    "(score +9.00)",
+			wantErr: false,
+		},
+		{
+			args: args{
+				move: decodeTestMoves(test, "c4b3")[0],
+				analysis: MoveAnalysis{
+					Score:        -0.5,
+					Continuation: decodeTestMoves(test, "a5b5", "b3c3"),
+				},
+				moveEncoder: san.EncodeMove,
+			},
+			want:    "(score -0.50, expected Kb5 Kc3+)",
+			wantErr: false,
+		},
+		{
+			args: args{
+				move:        decodeTestMoves(test, "b1b5")[0],
+				analysis:    MoveAnalysis{Score: 1e6},
+				moveEncoder: EncodeUCIMove,
+			},
+			want:    "(mate in 1)",
+			wantErr: false,
+		},
+		{
+			args: args{
+				move:        decodeTestMoves(test, "c4b3")[0],
+				analysis:    MoveAnalysis{Score: 1e6},
+				moveEncoder: EncodeUCIMove,
+			},
+			want:    "(mate)",
+			wantErr: false,
+		},
+		{
+			args: args{
+				move:        decodeTestMoves(test, "c4b3")[0],
+				analysis:    MoveAnalysis{Score: -1e6},
+				moveEncoder: EncodeUCIMove,
+			},
+			want:    "(mated)",
+			wantErr: false,
+		},
+	} {
+		got, gotErr := EncodeMoveAnalysis(
+			decodeTestStorage(test, mateInOne),
+			models.White,
+			data.args.move,
+			data.args.analysis,
+			data.args.moveEncoder,
+		)
+
+		if got != data.want {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withMoveAnalysis(test *testing.T) {
+	settings := newTestSearchSettings()
+	settings.Cache = newTestSearchCache()
+
+	var output bytes.Buffer
+	players := Players{
+		models.White: NewSearcherPlayer(settings),
+		models.Black: NewScriptedPlayer(nil),
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.Play(context.Background()) // nolint: errcheck
+
+	pattern := regexp.MustCompile(`white> \(searching\) \w+ \((score|mate)`)
+	if !pattern.MatchString(output.String()) {
+		test.Fail()
+	}
+}
@@ -0,0 +1,36 @@
+package game
+
+import (
+	"fmt"
+)
+
+// SetMoveList ...
+//
+// It displays a numbered list of moves to the right of the board
+// (by default: disabled).
+func (game *Game) SetMoveList(moveList bool) {
+	game.moveList = moveList
+}
+
+// it returns full moves by lines (e.g. "1. e2e3 b4b3")
+func (game *Game) encodeMoveList() ([]string, error) {
+	var lines []string
+	storage := game.history.InitialStorage()
+	for index, item := range game.history.Items() {
+		text, err := game.moveEncoder(storage, item.Move)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode the move: %s", err)
+		}
+
+		// white moves first
+		if index%2 == 0 {
+			lines = append(lines, fmt.Sprintf("%d. %s", index/2+1, text))
+		} else {
+			lines[len(lines)-1] += " " + text
+		}
+
+		storage = item.Storage
+	}
+
+	return lines, nil
+}
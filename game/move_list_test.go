@@ -0,0 +1,30 @@
+package game
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestGameEncodeMoveList(test *testing.T) {
+	for input, want := range map[string][]string{
+		"":                   nil,
+		"c4b3\n":             {"1. c4b3"},
+		"c4b3\na5b5\n":       {"1. c4b3 a5b5"},
+		"c4b3\na5b5\nb1b2\n": {"1. c4b3 a5b5", "2. b1b2"},
+	} {
+		game := newTestSessionGame(test, input)
+		if err := game.Play(context.Background()); err != io.EOF {
+			test.Fatal(err)
+		}
+
+		got, err := game.encodeMoveList()
+		if !reflect.DeepEqual(got, want) {
+			test.Fail()
+		}
+		if err != nil {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+// MoveEncoder ...
+//
+// It encodes the move, which is made in the storage.
+type MoveEncoder func(
+	storage models.PieceStorage,
+	move climodels.Move,
+) (string, error)
+
+// EncodeUCIMove ...
+//
+// It's an adapter of uci.EncodeMove() to the MoveEncoder type.
+// A promotion is encoded by a lowercase suffix (e.g. "a4a5q").
+func EncodeUCIMove(
+	storage models.PieceStorage,
+	move climodels.Move,
+) (string, error) {
+	text := uci.EncodeMove(move.Move)
+	if move.Promotion != models.King {
+		text += strings.ToLower(san.EncodeKind(move.Promotion))
+	}
+
+	return text, nil
+}
+
+// DecodeMove ...
+//
+// It accepts both pure coordinate and standard algebraic notations.
+// A promotion is optional, so the result can be a promotion
+// without a chosen kind.
+func DecodeMove(
+	storage models.PieceStorage,
+	color models.Color,
+	text string,
+) (climodels.Move, error) {
+	if move, err := decodeUCIMove(text); err == nil {
+		return move, nil
+	}
+
+	move, err := san.DecodeMove(storage, color, text)
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("unable to decode the move: %s", err)
+	}
+
+	return move, nil
+}
+
+func decodeUCIMove(text string) (climodels.Move, error) {
+	var promotion string
+	if len(text) == 5 {
+		text, promotion = text[:4], strings.ToUpper(text[4:])
+	}
+
+	move, err := uci.DecodeMove(text)
+	if err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	var promotionKind models.Kind
+	if promotion != "" {
+		promotionKind, err = san.DecodeKind(promotion)
+		if err != nil || !climodels.IsPromotionKind(promotionKind) {
+			return climodels.Move{}, errors.New("incorrect promotion")
+		}
+	}
+
+	return climodels.Move{Move: move, Promotion: promotionKind}, nil
+}
+
+func encodeMoves(
+	storage models.PieceStorage,
+	moves []models.Move,
+	moveEncoder MoveEncoder,
+) ([]string, error) {
+	var texts []string
+	for _, move := range moves {
+		fullMove := climodels.Move{Move: move}.WithDefaultPromotion(storage)
+		text, err := moveEncoder(storage, fullMove)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode the move: %s", err)
+		}
+
+		texts = append(texts, text)
+	}
+
+	return texts, nil
+}
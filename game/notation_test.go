@@ -0,0 +1,223 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	// white promotes a pawn by a4a5 or a4b5
+	promotion = "1r1k1/P4/5/5/4K"
+)
+
+func TestEncodeUCIMove(test *testing.T) {
+	type args struct {
+		fen  string
+		move climodels.Move
+	}
+	type data struct {
+		args args
+		want string
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen: mateInOne,
+				move: climodels.Move{
+					Move: models.Move{
+						Start:  models.Position{File: 1, Rank: 0},
+						Finish: models.Position{File: 1, Rank: 4},
+					},
+				},
+			},
+			want: "b1b5",
+		},
+		{
+			args: args{
+				fen: promotion,
+				move: climodels.Move{
+					Move: models.Move{
+						Start:  models.Position{File: 0, Rank: 3},
+						Finish: models.Position{File: 0, Rank: 4},
+					},
+					Promotion: models.Knight,
+				},
+			},
+			want: "a4a5n",
+		},
+	} {
+		storage := decodeTestStorage(test, data.args.fen)
+		got, gotErr := EncodeUCIMove(storage, data.args.move)
+
+		if got != data.want {
+			test.Fail()
+		}
+		if gotErr != nil {
+			test.Fail()
+		}
+	}
+}
+
+func TestDecodeMove(test *testing.T) {
+	type args struct {
+		fen  string
+		text string
+	}
+	type data struct {
+		args          args
+		wantMove      models.Move
+		wantPromotion models.Kind
+		wantErr       bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{mateInOne, "b1b5"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{mateInOne, "Qb5"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{mateInOne, "Kb3"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 2, Rank: 3},
+				Finish: models.Position{File: 1, Rank: 2},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{promotion, "a4a5r"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 0, Rank: 4},
+			},
+			wantPromotion: models.Rook,
+			wantErr:       false,
+		},
+		{
+			args: args{promotion, "axb5=B"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantPromotion: models.Bishop,
+			wantErr:       false,
+		},
+		{
+			// a promotion without a chosen kind
+			args: args{promotion, "a4a5"},
+			wantMove: models.Move{
+				Start:  models.Position{File: 0, Rank: 3},
+				Finish: models.Position{File: 0, Rank: 4},
+			},
+			wantPromotion: models.King,
+			wantErr:       false,
+		},
+		{
+			args:     args{promotion, "a4a5k"},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args:     args{mateInOne, "Nb3"},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args:     args{mateInOne, "incorrect"},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+	} {
+		gotMove, gotErr := DecodeMove(
+			decodeTestStorage(test, data.args.fen),
+			models.White,
+			data.args.text,
+		)
+
+		if gotMove.Move != data.wantMove {
+			test.Fail()
+		}
+		if gotMove.Promotion != data.wantPromotion {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestEncodeMoves(test *testing.T) {
+	type args struct {
+		fen         string
+		moves       []string
+		moveEncoder MoveEncoder
+	}
+	type data struct {
+		args      args
+		wantTexts []string
+		wantErr   bool
+	}
+
+	for _, data := range []data{
+		{
+			args:      args{mateInOne, []string{"b1b5", "c4b3"}, EncodeUCIMove},
+			wantTexts: []string{"b1b5", "c4b3"},
+			wantErr:   false,
+		},
+		{
+			args:      args{mateInOne, []string{"b1b5", "c4b3"}, san.EncodeMove},
+			wantTexts: []string{"Qb5#", "Kb3"},
+			wantErr:   false,
+		},
+		{
+			args:      args{promotion, []string{"a4a5", "a4b5"}, san.EncodeMove},
+			wantTexts: []string{"a5=Q", "axb5=Q+"},
+			wantErr:   false,
+		},
+		{
+			args:      args{mateInOne, nil, san.EncodeMove},
+			wantTexts: nil,
+			wantErr:   false,
+		},
+		{
+			// there is no piece on the start square
+			args:      args{mateInOne, []string{"a1a2"}, san.EncodeMove},
+			wantTexts: nil,
+			wantErr:   true,
+		},
+	} {
+		var moves []models.Move
+		for _, move := range decodeTestMoves(test, data.args.moves...) {
+			moves = append(moves, move.Move)
+		}
+
+		gotTexts, gotErr := encodeMoves(
+			decodeTestStorage(test, data.args.fen),
+			moves,
+			data.args.moveEncoder,
+		)
+
+		if !reflect.DeepEqual(gotTexts, data.wantTexts) {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
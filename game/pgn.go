@@ -0,0 +1,95 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// EncodePGN ...
+//
+// It detects a game result by an error returned by Game.Play(); pass nil
+// for an unfinished game.
+func (game *Game) EncodePGN(state error) (string, error) {
+	var moves []climodels.Move
+	for _, item := range game.history.Items() {
+		moves = append(moves, item.Move)
+	}
+
+	return pgn.EncodeGame(pgn.Game{
+		Header: pgn.Header{
+			Date:  game.startTime,
+			Round: "-",
+			White: game.playerName(models.White),
+			Black: game.playerName(models.Black),
+		},
+		InitialStorage: game.history.InitialStorage(),
+		Moves:          moves,
+		Result:         game.result(state),
+		Termination:    pgnTermination(state),
+		Tags:           game.pgnTags(state),
+	})
+}
+
+// SavePGN ...
+//
+// It detects a game result like Game.EncodePGN().
+func (game *Game) SavePGN(path string, state error) error {
+	text, err := game.EncodePGN(state)
+	if err != nil {
+		return fmt.Errorf("unable to encode the game: %s", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("unable to write the game: %s", err)
+	}
+
+	return nil
+}
+
+func (game *Game) playerName(color models.Color) string {
+	player, ok := game.players[color]
+	if !ok {
+		return ""
+	}
+
+	var name string
+	switch player.Side() {
+	case climodels.Human:
+		name = "Human"
+	case climodels.Searcher:
+		name = "Computer"
+	}
+
+	return name
+}
+
+// it returns a value of the Termination tag from the PGN standard
+func pgnTermination(state error) string {
+	switch {
+	case state == ErrTimeIsOver:
+		return "time forfeit"
+	case IsWin(state), IsDraw(state):
+		return "normal"
+	}
+
+	return ""
+}
+
+func (game *Game) result(state error) pgn.Result {
+	var result pgn.Result
+	switch {
+	case IsWin(state):
+		winner := game.Color().Negative()
+		result = pgn.NewWin(winner)
+	case IsDraw(state):
+		result = pgn.Draw
+	default:
+		result = pgn.Unknown
+	}
+
+	return result
+}
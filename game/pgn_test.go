@@ -0,0 +1,154 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestGameEncodePGN(test *testing.T) {
+	var output bytes.Buffer
+	players := Players{
+		models.White: NewScriptedPlayer(decodeTestMoves(test, "b1b5")),
+		models.Black: NewScriptedPlayer(nil),
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	state := game.Play(context.Background())
+	got, gotErr := game.EncodePGN(state)
+
+	for _, line := range []string{
+		"[Round \"-\"]\n",
+		"[White \"Computer\"]\n",
+		"[Black \"Computer\"]\n",
+		"[Result \"1-0\"]\n",
+		"[FEN \"" + mateInOne + " w - - 0 1\"]\n",
+		"\n1. Qb5# 1-0\n",
+	} {
+		if !strings.Contains(got, line) {
+			test.Fail()
+		}
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
+
+func TestGameResult(test *testing.T) {
+	type args struct {
+		state error
+	}
+	type data struct {
+		args args
+		want pgn.Result
+	}
+
+	for _, data := range []data{
+		{
+			args: args{minimax.ErrCheckmate},
+			want: pgn.BlackWin,
+		},
+		{
+			args: args{ErrResignation},
+			want: pgn.BlackWin,
+		},
+		{
+			args: args{ErrTimeIsOver},
+			want: pgn.BlackWin,
+		},
+		{
+			args: args{minimax.ErrDraw},
+			want: pgn.Draw,
+		},
+		{
+			args: args{ErrThreefoldRepetition},
+			want: pgn.Draw,
+		},
+		{
+			args: args{nil},
+			want: pgn.Unknown,
+		},
+	} {
+		game := NewGame(
+			nil,
+			newTestStorageEncoder(),
+			nil,
+			decodeTestStorage(test, mateInOne),
+		)
+		got := game.result(data.args.state)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestPGNTermination(test *testing.T) {
+	for state, want := range map[error]string{
+		minimax.ErrCheckmate:   "normal",
+		ErrResignation:         "normal",
+		ErrTimeIsOver:          "time forfeit",
+		ErrThreefoldRepetition: "normal",
+		ErrQuit:                "",
+		nil:                    "",
+	} {
+		if got := pgnTermination(state); got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withSaveCommand(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-chess-cli")
+	if err != nil {
+		test.Fatal(err)
+	}
+	defer os.RemoveAll(directory) // nolint: errcheck
+
+	path := filepath.Join(directory, "game.pgn")
+	var output bytes.Buffer
+	input := "c4b3\na5b5\nsave " + path + "\nsave\n"
+	human := NewHumanPlayer(
+		bufio.NewReader(strings.NewReader(input)),
+		ioutil.Discard,
+	)
+	players := Players{
+		models.White: human,
+		models.Black: human,
+	}
+	game := NewGame(
+		&output,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.Play(context.Background()) // nolint: errcheck
+
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	if !strings.Contains(string(text), "\n1. Kb3 Kb5 *\n") {
+		test.Fail()
+	}
+	if !strings.Contains(output.String(), "saved to "+path+"\n") {
+		test.Fail()
+	}
+	if !strings.Contains(output.String(), "error: unable to save the game: ") {
+		test.Fail()
+	}
+}
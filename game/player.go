@@ -0,0 +1,50 @@
+package game
+
+import (
+	"context"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// Player ...
+type Player interface {
+	// Side ...
+	//
+	// It describes how a player makes moves: interactively or automatically.
+	Side() climodels.Side
+
+	NextMove(
+		ctx context.Context,
+		storage models.PieceStorage,
+		color models.Color,
+	) (climodels.Move, error)
+}
+
+// Players ...
+type Players map[models.Color]Player
+
+// NewPlayers ...
+//
+// It assigns players to colors by a human color.
+func NewPlayers(
+	human Player,
+	searcher Player,
+	humanColor models.Color,
+) Players {
+	players := make(Players)
+	// white moves first, so an initial side is its side
+	side := climodels.NewSide(humanColor)
+	for _, color := range []models.Color{models.White, models.Black} {
+		switch side {
+		case climodels.Human:
+			players[color] = human
+		case climodels.Searcher:
+			players[color] = searcher
+		}
+
+		side = side.Invert()
+	}
+
+	return players
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestNewPlayers(test *testing.T) {
+	type args struct {
+		humanColor models.Color
+	}
+	type data struct {
+		args args
+		want Players
+	}
+
+	human := NewHumanPlayer(nil, nil)
+	searcher := NewSearcherPlayer(SearchSettings{})
+	for _, data := range []data{
+		{
+			args: args{models.Black},
+			want: Players{
+				models.White: searcher,
+				models.Black: human,
+			},
+		},
+		{
+			args: args{models.White},
+			want: Players{
+				models.White: human,
+				models.Black: searcher,
+			},
+		},
+	} {
+		got := NewPlayers(human, searcher, data.args.humanColor)
+
+		if !reflect.DeepEqual(got, data.want) {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"context"
+	"io"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// ScriptedPlayer ...
+//
+// It makes moves from a list in turn.
+type ScriptedPlayer struct {
+	moves []climodels.Move
+}
+
+// NewScriptedPlayer ...
+func NewScriptedPlayer(moves []climodels.Move) *ScriptedPlayer {
+	return &ScriptedPlayer{moves}
+}
+
+// Side ...
+func (player *ScriptedPlayer) Side() climodels.Side {
+	return climodels.Searcher
+}
+
+// NextMove ...
+//
+// It returns io.EOF when moves are over. A promotion without a chosen kind
+// is completed by climodels.DefaultPromotion.
+func (player *ScriptedPlayer) NextMove(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) (climodels.Move, error) {
+	if len(player.moves) == 0 {
+		return climodels.Move{}, io.EOF
+	}
+
+	move := player.moves[0]
+	player.moves = player.moves[1:]
+
+	if err := CheckMove(storage, color, move); err != nil {
+		return climodels.Move{}, err // don't wrap
+	}
+
+	return move.WithDefaultPromotion(storage), nil
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestScriptedPlayerSide(test *testing.T) {
+	got := NewScriptedPlayer(nil).Side()
+
+	if got != climodels.Searcher {
+		test.Fail()
+	}
+}
+
+func TestScriptedPlayerNextMove(test *testing.T) {
+	type args struct {
+		moves []climodels.Move
+	}
+	type data struct {
+		args     args
+		wantMove models.Move
+		wantErr  bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{decodeTestMoves(test, "b1b5", "b5b4")},
+			wantMove: models.Move{
+				Start:  models.Position{File: 1, Rank: 0},
+				Finish: models.Position{File: 1, Rank: 4},
+			},
+			wantErr: false,
+		},
+		{
+			args:     args{decodeTestMoves(test, "c4b4")},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+		{
+			args:     args{nil},
+			wantMove: models.Move{},
+			wantErr:  true,
+		},
+	} {
+		player := NewScriptedPlayer(data.args.moves)
+		gotMove, gotErr := player.NextMove(
+			context.Background(),
+			decodeTestStorage(test, mateInOne),
+			models.White,
+		)
+
+		if gotMove.Move != data.wantMove {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestScriptedPlayerNextMove_withMovesEnd(test *testing.T) {
+	player := NewScriptedPlayer(decodeTestMoves(test, "b1b5"))
+	storage := decodeTestStorage(test, mateInOne)
+	ctx := context.Background()
+	player.NextMove(ctx, storage, models.White) // nolint: errcheck
+	_, gotErr := player.NextMove(ctx, storage, models.White)
+
+	if gotErr != io.EOF {
+		test.Fail()
+	}
+}
+
+func TestScriptedPlayerNextMove_withPromotion(test *testing.T) {
+	type args struct {
+		moves []climodels.Move
+	}
+	type data struct {
+		args args
+		want models.Kind
+	}
+
+	for _, data := range []data{
+		{
+			args: args{decodeTestMoves(test, "a4a5")},
+			want: climodels.DefaultPromotion,
+		},
+		{
+			args: args{decodeTestMoves(test, "a4a5b")},
+			want: models.Bishop,
+		},
+	} {
+		player := NewScriptedPlayer(data.args.moves)
+		gotMove, gotErr := player.NextMove(
+			context.Background(),
+			decodeTestStorage(test, promotion),
+			models.White,
+		)
+
+		if gotMove.Promotion != data.want {
+			test.Fail()
+		}
+		if gotErr != nil {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"runtime"
+
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	"github.com/thewizardplusplus/go-chess-minimax/evaluators"
+	moves "github.com/thewizardplusplus/go-chess-minimax/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// Search ...
+func Search(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	terminator terminators.SearchTerminator,
+) (moves.ScoredMove, error) {
+	return SearchWithProgress(cache, storage, color, terminator, nil)
+}
+
+// SearchWithProgress ...
+//
+// It reports a search state to the progress, if it isn't nil.
+func SearchWithProgress(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	terminator terminators.SearchTerminator,
+	progress *SearchProgress,
+) (moves.ScoredMove, error) {
+	var generator minimax.MoveGenerator = models.MoveGenerator{}
+	if progress != nil {
+		generator = progressGenerator{generator, progress}
+		if cache != nil {
+			cache = progressCache{cache, progress}
+		}
+	}
+
+	searcher := minimax.NewParallelSearcher(
+		terminator,
+		runtime.NumCPU(),
+		func() minimax.MoveSearcher {
+			innerSearcher := minimax.NewAlphaBetaSearcher(
+				generator,
+				nil, // terminator will be set automatically by the iterative searcher
+				evaluators.MaterialEvaluator{},
+			)
+
+			if cache != nil {
+				// make and bind a cached searcher to inner one
+				minimax.NewCachedSearcher(innerSearcher, cache)
+			}
+
+			var iteratedSearcher minimax.MoveSearcher = innerSearcher
+			if progress != nil {
+				iteratedSearcher = &progressSearcher{
+					MoveSearcher: innerSearcher,
+					progress:     progress,
+				}
+			}
+
+			return minimax.NewIterativeSearcher(
+				iteratedSearcher,
+				nil, // terminator will be set automatically by the parallel searcher
+			)
+		},
+	)
+
+	return searcher.SearchMove(
+		storage,
+		color,
+		0, // initial deep
+		moves.NewBounds(),
+	)
+}
+
+// EnsureMove ...
+//
+// A search stopped before its first iteration returns a null move. Then
+// the search is repeated with the minimal deep, so a move is returned
+// whenever it exists.
+func EnsureMove(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	move moves.ScoredMove,
+) (moves.ScoredMove, error) {
+	if move.Move.Start != move.Move.Finish {
+		return move, nil
+	}
+
+	terminator := terminators.NewDeepTerminator(1)
+	return Search(cache, storage, color, terminator)
+}
+
+// Check ...
+//
+// It detects a game state for a color to move.
+func Check(storage models.PieceStorage, color models.Color) error {
+	// minimal deep, at which a game state will be detected
+	terminator := terminators.NewDeepTerminator(1)
+	_, err := Search(
+		nil, // without a cache
+		storage,
+		color,
+		terminator,
+	)
+	return err // don't wrap
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+)
+
+// SearchInfoMode ...
+type SearchInfoMode int
+
+// ...
+const (
+	WithoutSearchInfo SearchInfoMode = iota
+	// a search state is printed by separate lines
+	// (e.g. if an output is redirected)
+	PlainSearchInfo
+	// a search state is refreshed in place at the prompt line
+	// (e.g. on TTYs)
+	InPlaceSearchInfo
+)
+
+const (
+	plainSearchInfoInterval   = time.Second
+	inPlaceSearchInfoInterval = 100 * time.Millisecond
+	// it clears a terminal line and returns a cursor to its beginning
+	clearLineSequence = "\r\x1b[K"
+)
+
+// it returns a function to stop displaying
+func (game *Game) startSearchInfo(
+	progress *SearchProgress,
+	prompt string,
+) (stop func()) {
+	interval := plainSearchInfoInterval
+	if game.searchInfoMode == InPlaceSearchInfo {
+		interval = inPlaceSearchInfoInterval
+	}
+
+	stopping, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				game.writeSearchInfo(progress.Info(), prompt)
+			case <-stopping:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stopping)
+		<-done
+
+		// the final state is kept in the plain mode only
+		if game.searchInfoMode == InPlaceSearchInfo {
+			fmt.Fprint(game.writer, clearLineSequence+prompt) // nolint: errcheck
+			return
+		}
+
+		game.writeSearchInfo(progress.Info(), prompt)
+		fmt.Fprintln(game.writer) // nolint: errcheck
+	}
+}
+
+func (game *Game) writeSearchInfo(info SearchInfo, prompt string) {
+	text := game.encodeSearchInfo(info)
+	if game.searchInfoMode == InPlaceSearchInfo {
+		text = clearLineSequence + prompt + text
+	} else {
+		text = "\ninfo: " + text
+	}
+
+	fmt.Fprint(game.writer, text) // nolint: errcheck
+}
+
+func (game *Game) encodeSearchInfo(info SearchInfo) string {
+	var parts []string
+	if info.HasMove {
+		move := climodels.Move{Move: info.Move}.WithDefaultPromotion(game.Storage())
+		moveText, err := game.moveEncoder(game.Storage(), move)
+		if err != nil {
+			moveText = "?"
+		}
+
+		score := info.Score
+		if score == 0 {
+			score = 0 // replace the negative zero
+		}
+
+		parts = append(
+			parts,
+			fmt.Sprintf("depth %d", info.Deep),
+			"move "+moveText,
+			fmt.Sprintf("score %+.2f", score),
+		)
+	}
+
+	parts = append(
+		parts,
+		fmt.Sprintf("nodes %d (%d/s)", info.Nodes, info.NodesPerSecond()),
+		"time "+encodeDuration(info.Elapsed),
+	)
+	if info.CacheRequests != 0 {
+		hitRate := int(info.CacheHitRate() * 100)
+		parts = append(parts, fmt.Sprintf("cache hits %d%%", hitRate))
+	}
+
+	return strings.Join(parts, ", ")
+}
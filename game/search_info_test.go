@@ -0,0 +1,123 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestGamePlay_withSearchInfo(test *testing.T) {
+	type args struct {
+		searchInfoMode SearchInfoMode
+	}
+	type data struct {
+		args          args
+		wantOutput    []string
+		notWantOutput []string
+	}
+
+	for _, data := range []data{
+		{
+			args:          args{WithoutSearchInfo},
+			notWantOutput: []string{"nodes ", clearLineSequence},
+		},
+		{
+			args: args{PlainSearchInfo},
+			wantOutput: []string{
+				"white> (searching) \ninfo: depth ",
+				", score ",
+				", nodes ",
+				", time 0:0",
+				", cache hits ",
+			},
+			notWantOutput: []string{clearLineSequence},
+		},
+		{
+			args: args{InPlaceSearchInfo},
+			wantOutput: []string{
+				clearLineSequence + "white> (searching) ",
+			},
+			notWantOutput: []string{"info: "},
+		},
+	} {
+		settings := newTestSearchSettings()
+		// the in-place mode displays a state at intervals only
+		settings.Deep = 100
+		settings.Duration = 3 * inPlaceSearchInfoInterval
+		settings.Cache = newTestSearchCache()
+
+		var output bytes.Buffer
+		players := Players{
+			models.White: NewSearcherPlayer(settings),
+			models.Black: NewScriptedPlayer(nil),
+		}
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			players,
+			decodeTestStorage(test, mateInOne),
+		)
+		game.SetSearchInfoMode(data.args.searchInfoMode)
+		game.Play(context.Background()) // nolint: errcheck
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		for _, line := range data.notWantOutput {
+			if strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+	}
+}
+
+func TestGameEncodeSearchInfo(test *testing.T) {
+	type args struct {
+		info SearchInfo
+	}
+	type data struct {
+		args args
+		want string
+	}
+
+	for _, data := range []data{
+		{
+			args: args{SearchInfo{Nodes: 10, Elapsed: 100 * time.Millisecond}},
+			want: "nodes 10 (100/s), time 0:00.1",
+		},
+		{
+			args: args{
+				SearchInfo{
+					Deep:          3,
+					Move:          decodeTestMoves(test, "b1b5")[0].Move,
+					Score:         -1.5,
+					HasMove:       true,
+					Nodes:         2000,
+					CacheRequests: 4,
+					CacheHits:     3,
+					Elapsed:       2 * time.Second,
+				},
+			},
+			want: "depth 3, move b1b5, score -1.50, nodes 2000 (1000/s), " +
+				"time 0:02.0, cache hits 75%",
+		},
+	} {
+		game := NewGame(
+			nil,
+			newTestStorageEncoder(),
+			nil,
+			decodeTestStorage(test, mateInOne),
+		)
+		got := game.encodeSearchInfo(data.args.info)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	"github.com/thewizardplusplus/go-chess-minimax/evaluators"
+	moves "github.com/thewizardplusplus/go-chess-minimax/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// ScoredLine ...
+//
+// It's a root move with its analysis.
+type ScoredLine struct {
+	Move     climodels.Move
+	Analysis MoveAnalysis
+}
+
+type rootMoveResult struct {
+	line    ScoredLine
+	isLegal bool
+	err     error
+}
+
+// SearchLines ...
+//
+// Unlike Search(), it searches each root move with the full window, so scores
+// of several best moves are exact. It returns no more than the count of lines
+// sorted by their scores.
+//
+// The search is limited by the deep and also can be stopped
+// by the terminator. The cache is optional, but without it lines consist
+// of root moves only.
+func SearchLines(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	deep int,
+	terminator terminators.SearchTerminator,
+	count int,
+) ([]ScoredLine, error) {
+	generatedMoves, err := models.MoveGenerator{}.MovesForColor(storage, color)
+	if err != nil {
+		return nil, err // don't wrap
+	}
+
+	results := make([]rootMoveResult, len(generatedMoves))
+	semaphore := make(chan struct{}, runtime.NumCPU())
+	var waiter sync.WaitGroup
+	for index, move := range generatedMoves {
+		semaphore <- struct{}{}
+		waiter.Add(1)
+
+		go func(index int, move models.Move) {
+			defer func() {
+				<-semaphore
+				waiter.Done()
+			}()
+
+			results[index] = searchRootMove(
+				cache,
+				storage,
+				color,
+				climodels.Move{Move: move}.WithDefaultPromotion(storage),
+				deep,
+				terminator,
+			)
+		}(index, move)
+	}
+	waiter.Wait()
+
+	var lines []ScoredLine
+	for _, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		if result.isLegal {
+			lines = append(lines, result.line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, Check(storage, color)
+	}
+
+	// the stable sorting keeps an order of the generator for equal scores
+	sort.SliceStable(lines, func(i int, j int) bool {
+		return lines[i].Analysis.Score > lines[j].Analysis.Score
+	})
+	if len(lines) > count {
+		lines = lines[:count]
+	}
+
+	return lines, nil
+}
+
+func searchRootMove(
+	cache caches.Cache,
+	storage models.PieceStorage,
+	color models.Color,
+	move climodels.Move,
+	deep int,
+	terminator terminators.SearchTerminator,
+) rootMoveResult {
+	var searcher minimax.MoveSearcher = minimax.NewAlphaBetaSearcher(
+		models.MoveGenerator{},
+		terminators.NewGroupTerminator(
+			terminators.NewDeepTerminator(deep),
+			terminator,
+		),
+		evaluators.MaterialEvaluator{},
+	)
+	if cache != nil {
+		// the cached searcher is used for the root too,
+		// so the continuation of the move is cached
+		searcher = minimax.NewCachedSearcher(searcher, cache)
+	}
+
+	nextMove, err := searcher.SearchMove(
+		move.Apply(storage),
+		color.Negative(),
+		1, // the root move is already made
+		moves.NewBounds(),
+	)
+	switch err {
+	case nil, minimax.ErrCheckmate, minimax.ErrDraw:
+	case models.ErrKingCapture:
+		// the root move leaves the king under attack
+		return rootMoveResult{}
+	default:
+		return rootMoveResult{err: err}
+	}
+
+	analysis := MoveAnalysis{
+		Score:        -nextMove.Score,
+		Continuation: expectedContinuation(cache, storage, color, move),
+	}
+	return rootMoveResult{
+		line:    ScoredLine{Move: move, Analysis: analysis},
+		isLegal: true,
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// the search is limited by the deep argument only
+const unlimitedTestDeep = 1000
+
+func TestSearchLines(test *testing.T) {
+	type args struct {
+		fen   string
+		deep  int
+		count int
+	}
+	type data struct {
+		args          args
+		wantCount     int
+		wantMateCount int
+		wantErr       error
+	}
+
+	legalMoves, err := san.CorrectMoves(
+		decodeTestStorage(test, mateInOne),
+		models.White,
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen:   mateInOne,
+				deep:  2,
+				count: 2,
+			},
+			// b1b5, b1b4 and b1a1 are all mates
+			wantCount:     2,
+			wantMateCount: 2,
+			wantErr:       nil,
+		},
+		{
+			args: args{
+				fen:   mateInOne,
+				deep:  1,
+				count: 100,
+			},
+			wantCount:     len(legalMoves),
+			wantMateCount: 0,
+			wantErr:       nil,
+		},
+		{
+			args: args{
+				// the white king is mated by the black queen
+				fen:   "Kq3/2k2/5/5/5",
+				deep:  2,
+				count: 2,
+			},
+			wantCount:     0,
+			wantMateCount: 0,
+			wantErr:       minimax.ErrCheckmate,
+		},
+	} {
+		gotLines, gotErr := SearchLines(
+			newTestSearchCache(),
+			decodeTestStorage(test, data.args.fen),
+			models.White,
+			data.args.deep,
+			terminators.NewDeepTerminator(unlimitedTestDeep),
+			data.args.count,
+		)
+
+		if len(gotLines) != data.wantCount {
+			test.Fail()
+		}
+
+		var gotMateCount int
+		for index, line := range gotLines {
+			if line.Analysis.Score >= mateScore {
+				gotMateCount++
+			}
+			if index > 0 && line.Analysis.Score > gotLines[index-1].Analysis.Score {
+				test.Fail()
+			}
+		}
+		if gotMateCount != data.wantMateCount {
+			test.Fail()
+		}
+
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
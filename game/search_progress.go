@@ -0,0 +1,177 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	moves "github.com/thewizardplusplus/go-chess-minimax/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// SearchInfo ...
+//
+// It describes a search state: a deep of the last iteration, a best move
+// of that iteration and its score, and statistics of the whole search.
+type SearchInfo struct {
+	Deep          int
+	Move          models.Move
+	Score         float64
+	HasMove       bool
+	Nodes         int
+	CacheRequests int
+	CacheHits     int
+	Elapsed       time.Duration
+}
+
+// NodesPerSecond ...
+func (info SearchInfo) NodesPerSecond() int {
+	if info.Elapsed <= 0 {
+		return 0
+	}
+
+	return int(float64(info.Nodes) / info.Elapsed.Seconds())
+}
+
+// CacheHitRate ...
+//
+// It returns a share of cache requests, which found a move (from 0 to 1).
+func (info SearchInfo) CacheHitRate() float64 {
+	if info.CacheRequests == 0 {
+		return 0
+	}
+
+	return float64(info.CacheHits) / float64(info.CacheRequests)
+}
+
+// SearchProgress ...
+//
+// It collects a search state from several parallel searchers.
+type SearchProgress struct {
+	locker    sync.Mutex
+	clock     terminators.Clock
+	startTime time.Time
+	info      SearchInfo
+}
+
+// NewSearchProgress ...
+//
+// It starts counting an elapsed time.
+func NewSearchProgress(clock terminators.Clock) *SearchProgress {
+	return &SearchProgress{clock: clock, startTime: clock()}
+}
+
+// Info ...
+func (progress *SearchProgress) Info() SearchInfo {
+	progress.locker.Lock()
+	defer progress.locker.Unlock()
+
+	info := progress.info
+	info.Elapsed = progress.clock().Sub(progress.startTime)
+	return info
+}
+
+func (progress *SearchProgress) addNode() {
+	progress.locker.Lock()
+	defer progress.locker.Unlock()
+
+	progress.info.Nodes++
+}
+
+func (progress *SearchProgress) addCacheRequest(isHit bool) {
+	progress.locker.Lock()
+	defer progress.locker.Unlock()
+
+	progress.info.CacheRequests++
+	if isHit {
+		progress.info.CacheHits++
+	}
+}
+
+// parallel searchers report iterations independently,
+// so only the deepest ones are taken into account
+func (progress *SearchProgress) addIteration(deep int, move moves.ScoredMove) {
+	progress.locker.Lock()
+	defer progress.locker.Unlock()
+
+	if deep < progress.info.Deep {
+		return
+	}
+
+	progress.info.Deep = deep
+	progress.info.Move = move.Move
+	progress.info.Score = move.Score
+	progress.info.HasMove = true
+}
+
+type progressKey struct{}
+
+func withSearchProgress(
+	ctx context.Context,
+	progress *SearchProgress,
+) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+func contextSearchProgress(ctx context.Context) (*SearchProgress, bool) {
+	progress, ok := ctx.Value(progressKey{}).(*SearchProgress)
+	return progress, ok
+}
+
+type progressGenerator struct {
+	minimax.MoveGenerator
+
+	progress *SearchProgress
+}
+
+// each generation means a visit of a new node
+func (generator progressGenerator) MovesForColor(
+	storage models.PieceStorage,
+	color models.Color,
+) ([]models.Move, error) {
+	generator.progress.addNode()
+	return generator.MoveGenerator.MovesForColor(storage, color)
+}
+
+type progressCache struct {
+	caches.Cache
+
+	progress *SearchProgress
+}
+
+func (cache progressCache) Get(
+	storage models.PieceStorage,
+	color models.Color,
+) (moves.FailedMove, bool) {
+	move, ok := cache.Cache.Get(storage, color)
+	cache.progress.addCacheRequest(ok)
+
+	return move, ok
+}
+
+// it's called by an iterative searcher once per iteration
+type progressSearcher struct {
+	minimax.MoveSearcher
+
+	progress *SearchProgress
+	deep     int
+}
+
+func (searcher *progressSearcher) SearchMove(
+	storage models.PieceStorage,
+	color models.Color,
+	deep int,
+	bounds moves.Bounds,
+) (moves.ScoredMove, error) {
+	searcher.deep++
+
+	move, err := searcher.MoveSearcher.SearchMove(storage, color, deep, bounds)
+	if err == nil {
+		searcher.progress.addIteration(searcher.deep, move)
+	}
+
+	return move, err
+}
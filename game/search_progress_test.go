@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	moves "github.com/thewizardplusplus/go-chess-minimax/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestSearchInfoNodesPerSecond(test *testing.T) {
+	type args struct {
+		nodes   int
+		elapsed time.Duration
+	}
+	type data struct {
+		args args
+		want int
+	}
+
+	for _, data := range []data{
+		{
+			args: args{nodes: 1000, elapsed: 2 * time.Second},
+			want: 500,
+		},
+		{
+			args: args{nodes: 1000, elapsed: 0},
+			want: 0,
+		},
+	} {
+		info := SearchInfo{Nodes: data.args.nodes, Elapsed: data.args.elapsed}
+		got := info.NodesPerSecond()
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestSearchInfoCacheHitRate(test *testing.T) {
+	type args struct {
+		cacheRequests int
+		cacheHits     int
+	}
+	type data struct {
+		args args
+		want float64
+	}
+
+	for _, data := range []data{
+		{
+			args: args{cacheRequests: 4, cacheHits: 1},
+			want: 0.25,
+		},
+		{
+			args: args{cacheRequests: 0, cacheHits: 0},
+			want: 0,
+		},
+	} {
+		info := SearchInfo{
+			CacheRequests: data.args.cacheRequests,
+			CacheHits:     data.args.cacheHits,
+		}
+		got := info.CacheHitRate()
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestSearchProgress(test *testing.T) {
+	clock := &fakeClock{}
+	progress := NewSearchProgress(clock.now)
+	progress.addNode()
+	progress.addNode()
+	progress.addCacheRequest(true)
+	progress.addCacheRequest(false)
+	progress.addIteration(2, moves.ScoredMove{
+		Move:  models.Move{Finish: models.Position{File: 1}},
+		Score: 1,
+	})
+	// a shallower iteration of a parallel searcher is ignored
+	progress.addIteration(1, moves.ScoredMove{Score: 2})
+	clock.advance(time.Second)
+	got := progress.Info()
+
+	want := SearchInfo{
+		Deep:          2,
+		Move:          models.Move{Finish: models.Position{File: 1}},
+		Score:         1,
+		HasMove:       true,
+		Nodes:         2,
+		CacheRequests: 2,
+		CacheHits:     1,
+		Elapsed:       time.Second,
+	}
+	if got != want {
+		test.Fail()
+	}
+}
+
+func TestSearchWithProgress(test *testing.T) {
+	cache := newTestSearchCache()
+	progress := NewSearchProgress(time.Now)
+	ctx := withSearchProgress(context.Background(), progress)
+	gotProgress, ok := contextSearchProgress(ctx)
+	_, gotErr := SearchWithProgress(
+		cache,
+		decodeTestStorage(test, mateInOne),
+		models.White,
+		terminators.NewDeepTerminator(2),
+		gotProgress,
+	)
+	info := progress.Info()
+
+	if !ok || gotProgress != progress {
+		test.Fail()
+	}
+	if !info.HasMove || info.Deep == 0 || info.Nodes == 0 {
+		test.Fail()
+	}
+	if info.CacheRequests == 0 {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	"github.com/thewizardplusplus/go-chess-minimax/caches"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// SearchSettings ...
+type SearchSettings struct {
+	Cache    caches.Cache
+	Deep     int
+	Duration time.Duration
+	// it's a score (in pawns), by which a searcher underestimates a draw
+	// when it answers a draw offer
+	Contempt float64
+}
+
+// SearcherPlayer ...
+type SearcherPlayer struct {
+	settings SearchSettings
+}
+
+// NewSearcherPlayer ...
+func NewSearcherPlayer(settings SearchSettings) SearcherPlayer {
+	return SearcherPlayer{settings}
+}
+
+// Side ...
+func (player SearcherPlayer) Side() climodels.Side {
+	return climodels.Searcher
+}
+
+// NextMove ...
+//
+// Deadlines of the context (see Game.SetTimeManager()) replace a search
+// duration from the settings. On the context end (see Game.SetInterrupts()),
+// the search is stopped and the best move found so far is returned.
+// A search state is reported to a progress of the context
+// (see Game.SetSearchInfoMode()), a score of the move and its expected
+// continuation are reported to an analysis of the context.
+func (player SearcherPlayer) NextMove(
+	ctx context.Context,
+	storage models.PieceStorage,
+	color models.Color,
+) (climodels.Move, error) {
+	terminator := player.makeTerminator(ctx)
+	progress, _ := contextSearchProgress(ctx)
+	move, _ := SearchWithProgress( // nolint: gosec
+		player.settings.Cache,
+		storage,
+		color,
+		terminator,
+		progress,
+	)
+	move, err := EnsureMove(player.settings.Cache, storage, color, move)
+	if err != nil {
+		return climodels.Move{}, fmt.Errorf("unable to search the move: %s", err)
+	}
+
+	fullMove := climodels.Move{Move: move.Move}
+	fullMove = fullMove.WithDefaultPromotion(storage)
+	if holder, ok := contextMoveAnalysis(ctx); ok {
+		holder.analysis = MoveAnalysis{
+			Score: move.Score,
+			Continuation: expectedContinuation(
+				player.settings.Cache,
+				storage,
+				color,
+				fullMove,
+			),
+		}
+		holder.isSet = true
+	}
+
+	return fullMove, nil
+}
+
+// AcceptDraw ...
+//
+// It accepts a draw, if a score of the position for its color doesn't exceed
+// the negative contempt from the settings (i.e. a positive contempt makes it
+// to avoid draws).
+// Deadlines of the context replace a search duration from the settings
+// like in SearcherPlayer.NextMove().
+func (player SearcherPlayer) AcceptDraw(
+	ctx context.Context,
+	storage models.PieceStorage,
+	colorToMove models.Color,
+	color models.Color,
+) (bool, error) {
+	cache := player.settings.Cache
+	terminator := player.makeTerminator(ctx)
+	move, err := Search(cache, storage, colorToMove, terminator)
+	if err == nil {
+		// a score of a null move is meaningless
+		move, err = EnsureMove(cache, storage, colorToMove, move)
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to evaluate the draw: %s", err)
+	}
+
+	score := move.Score
+	if colorToMove != color {
+		score = -score
+	}
+
+	return score <= -player.settings.Contempt, nil
+}
+
+// deadlines of the context replace a search duration from the settings
+func (player SearcherPlayer) makeTerminator(
+	ctx context.Context,
+) terminators.SearchTerminator {
+	hardDeadline := player.settings.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		hardDeadline = time.Until(deadline)
+	}
+
+	softDeadline := hardDeadline
+	if deadline, ok := contextSoftDeadline(ctx); ok {
+		softDeadline = time.Until(deadline)
+	}
+
+	return terminators.NewGroupTerminator(
+		terminators.NewDeepTerminator(player.settings.Deep),
+		NewDeadlineTerminator(time.Now, softDeadline, hardDeadline),
+		NewContextTerminator(ctx),
+	)
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func TestSearcherPlayerSide(test *testing.T) {
+	got := NewSearcherPlayer(SearchSettings{}).Side()
+
+	if got != climodels.Searcher {
+		test.Fail()
+	}
+}
+
+func TestSearcherPlayerNextMove(test *testing.T) {
+	storage := decodeTestStorage(test, mateInOne)
+	player := NewSearcherPlayer(newTestSearchSettings())
+	gotMove, gotErr := player.NextMove(
+		context.Background(),
+		storage,
+		models.White,
+	)
+
+	nextStorage := gotMove.Apply(storage)
+	if err := Check(nextStorage, models.Black); err != minimax.ErrCheckmate {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
+
+func TestSearcherPlayerNextMove_withDeadlines(test *testing.T) {
+	storage := decodeTestStorage(test, mateInOne)
+	ctx, cancel := withDeadlines(
+		context.Background(),
+		time.Now(),
+		Deadlines{Soft: 100 * time.Millisecond, Hard: time.Second},
+	)
+	defer cancel()
+
+	player := NewSearcherPlayer(SearchSettings{Deep: 2, Duration: time.Hour})
+	gotMove, gotErr := player.NextMove(ctx, storage, models.White)
+
+	nextStorage := gotMove.Apply(storage)
+	if err := Check(nextStorage, models.Black); err != minimax.ErrCheckmate {
+		test.Fail()
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
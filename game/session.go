@@ -0,0 +1,102 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// nolint: gochecknoglobals
+var clockTags = map[models.Color]string{
+	models.White: "WhiteClock",
+	models.Black: "BlackClock",
+}
+
+// SetSessionTags ...
+//
+// It sets additional tags of saved games (e.g. settings of a session
+// in order to resume it). Remaining times of the clock are added to them
+// automatically.
+func (game *Game) SetSessionTags(tags map[string]string) {
+	game.sessionTags = tags
+}
+
+// Resume ...
+//
+// It replays moves of the saved game from its initial position and restores
+// remaining times of the clock, if they're saved. Sides of moves are taken
+// from players of the game.
+func (game *Game) Resume(savedGame pgn.Game) error {
+	game.history = NewHistory(savedGame.InitialStorage)
+	game.resetHint()
+	if !savedGame.Header.Date.IsZero() {
+		game.startTime = savedGame.Header.Date
+	}
+
+	for index, move := range savedGame.Moves {
+		if err := CheckMove(game.Storage(), game.Color(), move); err != nil {
+			return fmt.Errorf("unable to resume the move #%d: %s", index+1, err)
+		}
+
+		game.history.Push(HistoryItem{
+			Move:    move,
+			Storage: move.Apply(game.Storage()),
+			Side:    game.players[game.Color()].Side(),
+		})
+	}
+
+	if game.clock == nil {
+		return nil
+	}
+
+	game.clock.Reset()
+	for color, tag := range clockTags {
+		text, ok := savedGame.Tags[tag]
+		if !ok {
+			continue
+		}
+
+		remainingTime, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("unable to decode the %s tag: %s", tag, err)
+		}
+
+		// white moves first, so it makes an extra move on an odd count
+		moveCount := len(savedGame.Moves) / 2
+		if color == models.White {
+			moveCount += len(savedGame.Moves) % 2
+		}
+
+		game.clock.Restore(color, remainingTime, moveCount)
+	}
+
+	return nil
+}
+
+// the Termination tag allows standard values only,
+// so the detailed reason is saved in this one
+const terminationDetailsTag = "TerminationDetails"
+
+func (game *Game) pgnTags(state error) map[string]string {
+	termination := game.Termination(state)
+	if len(game.sessionTags) == 0 && game.clock == nil && termination == "" {
+		return nil
+	}
+
+	tags := make(map[string]string)
+	for name, value := range game.sessionTags {
+		tags[name] = value
+	}
+	if termination != "" {
+		tags[terminationDetailsTag] = termination
+	}
+	if game.clock != nil {
+		for color, tag := range clockTags {
+			tags[tag] = game.clock.RemainingTime(color).String()
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+func newTestSessionGame(test *testing.T, input string) *Game {
+	human := NewHumanPlayer(
+		bufio.NewReader(strings.NewReader(input)),
+		ioutil.Discard,
+	)
+	players := Players{
+		models.White: human,
+		models.Black: human,
+	}
+	game := NewGame(
+		ioutil.Discard,
+		newTestStorageEncoder(),
+		players,
+		decodeTestStorage(test, mateInOne),
+	)
+	game.SetClock(NewClock(TimeControl{Base: time.Minute}, time.Now))
+
+	return game
+}
+
+func TestGameResume(test *testing.T) {
+	game := newTestSessionGame(test, "c4b3\na5b5\n")
+	game.SetSessionTags(map[string]string{"FlagDeep": "3"})
+	if err := game.Play(context.Background()); err != io.EOF {
+		test.Fatal(err)
+	}
+
+	text, err := game.EncodePGN(nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	savedGames, err := pgn.DecodeGames(text)
+	if err != nil || len(savedGames) != 1 {
+		test.Fatal(err)
+	}
+	if savedGames[0].Tags["FlagDeep"] != "3" {
+		test.Fail()
+	}
+
+	resumedGame := newTestSessionGame(test, "")
+	gotErr := resumedGame.Resume(savedGames[0])
+
+	if len(resumedGame.History().Items()) != 2 {
+		test.Fail()
+	}
+	if resumedGame.Color() != models.White {
+		test.Fail()
+	}
+	if resumedGame.History().Items()[0].Side != game.History().Items()[0].Side {
+		test.Fail()
+	}
+	// the clock of the saved game is still running for white
+	for _, color := range []models.Color{models.White, models.Black} {
+		wantTime := game.clock.RemainingTime(color)
+		gotTime := resumedGame.clock.RemainingTime(color)
+		if gotTime < wantTime || gotTime > wantTime+time.Second {
+			test.Fail()
+		}
+	}
+	if gotErr != nil {
+		test.Fail()
+	}
+}
+
+func TestGameResume_withIncorrectMove(test *testing.T) {
+	savedGame := pgn.Game{
+		InitialStorage: decodeTestStorage(test, mateInOne),
+		// white moves a black piece
+		Moves: decodeTestMoves(test, "a5a4"),
+	}
+
+	game := newTestSessionGame(test, "")
+	gotErr := game.Resume(savedGame)
+
+	if gotErr == nil {
+		test.Fail()
+	}
+}
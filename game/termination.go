@@ -0,0 +1,217 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+// ...
+var (
+	ErrStalemate            = errors.New("stalemate")
+	ErrInsufficientMaterial = errors.New("insufficient material")
+	ErrFiftyMoves           = errors.New("fifty-move rule")
+	ErrSeventyFiveMoves     = errors.New("seventy-five-move rule")
+	ErrThreefoldRepetition  = errors.New("threefold repetition")
+	ErrFivefoldRepetition   = errors.New("fivefold repetition")
+)
+
+// nolint: gochecknoglobals
+var terminationReasons = map[error]string{
+	minimax.ErrCheckmate:    "by checkmate",
+	ErrResignation:          "by resignation",
+	ErrTimeIsOver:           "on time",
+	minimax.ErrDraw:         "by stalemate", // a search reports a stalemate so
+	ErrStalemate:            "by stalemate",
+	ErrInsufficientMaterial: "by insufficient material",
+	ErrFiftyMoves:           "by the fifty-move rule",
+	ErrSeventyFiveMoves:     "by the seventy-five-move rule",
+	ErrThreefoldRepetition:  "by threefold repetition",
+	ErrFivefoldRepetition:   "by fivefold repetition",
+	ErrDrawByAgreement:      "by agreement",
+}
+
+const (
+	// the limits are in halfmoves
+	fiftyMoveLimit       = 100
+	seventyFiveMoveLimit = 150
+	threefoldLimit       = 3
+	fivefoldLimit        = 5
+)
+
+// IsWin ...
+//
+// It reports whether the state returned by Game.Play() means a win
+// of the opponent of a color to move.
+func IsWin(state error) bool {
+	switch state {
+	case minimax.ErrCheckmate, ErrResignation, ErrTimeIsOver:
+		return true
+	}
+
+	return false
+}
+
+// IsDraw ...
+//
+// It reports whether the state returned by Game.Play() means a draw.
+func IsDraw(state error) bool {
+	switch state {
+	case minimax.ErrDraw, ErrStalemate, ErrInsufficientMaterial, ErrFiftyMoves,
+		ErrSeventyFiveMoves, ErrThreefoldRepetition, ErrFivefoldRepetition,
+		ErrDrawByAgreement:
+		return true
+	}
+
+	return false
+}
+
+// Termination ...
+//
+// It describes the game end by the state returned by Game.Play()
+// (e.g. "white wins by checkmate", "black wins on time" or "draw
+// by stalemate"). It returns an empty string for an unfinished game.
+func (game *Game) Termination(state error) string {
+	return Termination(state, game.Color())
+}
+
+// Termination ...
+//
+// It is the same as Game.Termination(), but it takes a color to move
+// explicitly (e.g. for a state returned by a search).
+func Termination(state error, color models.Color) string {
+	switch {
+	case IsWin(state):
+		winner := ascii.EncodeColor(color.Negative())
+		return fmt.Sprintf("%s wins %s", winner, terminationReasons[state])
+	case IsDraw(state):
+		return fmt.Sprintf("draw %s", terminationReasons[state])
+	}
+
+	return ""
+}
+
+// it detects the game end before a move of the player; a draw, which can be
+// claimed, is claimed by a searcher automatically and is reported to a human
+func (game *Game) checkState(player Player) error {
+	switch err := Check(game.Storage(), game.Color()); err {
+	case nil:
+	case minimax.ErrDraw:
+		return ErrStalemate
+	default:
+		return err // don't wrap
+	}
+
+	if hasInsufficientMaterial(game.Storage()) {
+		return ErrInsufficientMaterial
+	}
+
+	halfmoveClock, repetitionCount := game.drawCounters()
+	switch {
+	case halfmoveClock >= seventyFiveMoveLimit:
+		return ErrSeventyFiveMoves
+	case repetitionCount >= fivefoldLimit:
+		return ErrFivefoldRepetition
+	}
+
+	claim := game.drawClaim()
+	if claim == nil {
+		return nil
+	}
+	if player.Side() == climodels.Searcher {
+		return claim
+	}
+
+	fmt.Fprintf( // nolint: errcheck
+		game.writer,
+		"draw can be claimed: %s (by the claim command)\n",
+		claim,
+	)
+	return nil
+}
+
+// it returns nil, if a draw can't be claimed
+func (game *Game) drawClaim() error {
+	halfmoveClock, repetitionCount := game.drawCounters()
+	switch {
+	case halfmoveClock >= fiftyMoveLimit:
+		return ErrFiftyMoves
+	case repetitionCount >= threefoldLimit:
+		return ErrThreefoldRepetition
+	}
+
+	return nil
+}
+
+// it returns a count of halfmoves since the last capture or pawn move
+// and a count of repetitions of the current position
+func (game *Game) drawCounters() (halfmoveClock int, repetitionCount int) {
+	storage, color := game.history.InitialStorage(), models.White
+	positions := map[string]int{encodePosition(storage, color): 1}
+	for _, item := range game.history.Items() {
+		if isIrreversibleMove(storage, item.Move) {
+			// positions before the move can't be repeated
+			halfmoveClock, positions = 0, make(map[string]int)
+		} else {
+			halfmoveClock++
+		}
+
+		storage, color = item.Storage, color.Negative()
+		positions[encodePosition(storage, color)]++
+	}
+
+	return halfmoveClock, positions[encodePosition(storage, color)]
+}
+
+// castlings and en passant aren't supported, so a position is identified
+// by pieces and a color to move only
+func encodePosition(storage models.PieceStorage, color models.Color) string {
+	return uci.EncodePieceStorage(storage) + " " + ascii.EncodeColor(color)
+}
+
+// it reports whether the move is a capture or a pawn move
+func isIrreversibleMove(storage models.PieceStorage, move climodels.Move) bool {
+	if _, ok := storage.Piece(move.Finish); ok {
+		return true
+	}
+
+	piece, ok := storage.Piece(move.Start)
+	return ok && piece.Kind() == models.Pawn
+}
+
+// it detects positions, where a checkmate is impossible: kings
+// with a single knight or with bishops on squares of a same color
+func hasInsufficientMaterial(storage models.PieceStorage) bool {
+	var knightCount int
+	bishopSquareColors := make(map[int]struct{})
+	for _, position := range storage.Size().Positions() {
+		piece, ok := storage.Piece(position)
+		if !ok {
+			continue
+		}
+
+		switch piece.Kind() {
+		case models.King:
+		case models.Knight:
+			knightCount++
+		case models.Bishop:
+			bishopSquareColors[(position.File+position.Rank)%2] = struct{}{}
+		default:
+			return false
+		}
+	}
+
+	switch knightCount {
+	case 0:
+		return len(bishopSquareColors) <= 1
+	case 1:
+		return len(bishopSquareColors) == 0
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+const (
+	// kings can walk between the a and b files
+	kingsWithRooks = "k3r/5/5/5/K3R"
+	// the same position is repeated three times
+	threefoldMoves = "a1b1\na5b5\nb1a1\nb5a5\na1b1\na5b5\nb1a1\nb5a5\n"
+)
+
+func TestIsWin(test *testing.T) {
+	for state, want := range map[error]bool{
+		minimax.ErrCheckmate:   true,
+		ErrResignation:         true,
+		ErrTimeIsOver:          true,
+		ErrStalemate:           false,
+		ErrThreefoldRepetition: false,
+		nil:                    false,
+	} {
+		if got := IsWin(state); got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestIsDraw(test *testing.T) {
+	for state, want := range map[error]bool{
+		minimax.ErrDraw:         true,
+		ErrStalemate:            true,
+		ErrInsufficientMaterial: true,
+		ErrFiftyMoves:           true,
+		ErrSeventyFiveMoves:     true,
+		ErrThreefoldRepetition:  true,
+		ErrFivefoldRepetition:   true,
+		minimax.ErrCheckmate:    false,
+		ErrQuit:                 false,
+		nil:                     false,
+	} {
+		if got := IsDraw(state); got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestGameTermination(test *testing.T) {
+	for state, want := range map[error]string{
+		minimax.ErrCheckmate:   "black wins by checkmate",
+		ErrResignation:         "black wins by resignation",
+		ErrTimeIsOver:          "black wins on time",
+		ErrStalemate:           "draw by stalemate",
+		ErrThreefoldRepetition: "draw by threefold repetition",
+		ErrDrawByAgreement:     "draw by agreement",
+		ErrQuit:                "",
+		nil:                    "",
+	} {
+		game := NewGame(
+			nil,
+			newTestStorageEncoder(),
+			nil,
+			decodeTestStorage(test, mateInOne),
+		)
+		if got := game.Termination(state); got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestTermination(test *testing.T) {
+	for state, want := range map[error]string{
+		minimax.ErrCheckmate: "white wins by checkmate",
+		minimax.ErrDraw:      "draw by stalemate",
+		nil:                  "",
+	} {
+		if got := Termination(state, models.Black); got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestGameDrawCounters(test *testing.T) {
+	type args struct {
+		fen   string
+		moves []string
+	}
+	type data struct {
+		args                args
+		wantHalfmoveClock   int
+		wantRepetitionCount int
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				fen:   kingsWithRooks,
+				moves: nil,
+			},
+			wantHalfmoveClock:   0,
+			wantRepetitionCount: 1,
+		},
+		{
+			args: args{
+				fen:   kingsWithRooks,
+				moves: []string{"a1b1", "a5b5", "b1a1"},
+			},
+			wantHalfmoveClock:   3,
+			wantRepetitionCount: 1,
+		},
+		{
+			args: args{
+				fen:   kingsWithRooks,
+				moves: []string{"a1b1", "a5b5", "b1a1", "b5a5"},
+			},
+			wantHalfmoveClock:   4,
+			wantRepetitionCount: 2,
+		},
+		{
+			// the capture resets the counters
+			args: args{
+				fen:   kingsWithRooks,
+				moves: []string{"a1b1", "a5b5", "b1a1", "b5a5", "e1e5"},
+			},
+			wantHalfmoveClock:   0,
+			wantRepetitionCount: 1,
+		},
+	} {
+		storage := decodeTestStorage(test, data.args.fen)
+		game := NewGame(nil, newTestStorageEncoder(), nil, storage)
+		for _, move := range decodeTestMoves(test, data.args.moves...) {
+			storage = move.Apply(storage)
+			game.History().Push(HistoryItem{Move: move, Storage: storage})
+		}
+
+		gotHalfmoveClock, gotRepetitionCount := game.drawCounters()
+
+		if gotHalfmoveClock != data.wantHalfmoveClock {
+			test.Fail()
+		}
+		if gotRepetitionCount != data.wantRepetitionCount {
+			test.Fail()
+		}
+	}
+}
+
+func TestHasInsufficientMaterial(test *testing.T) {
+	for fen, want := range map[string]bool{
+		"k4/5/5/5/K4":    true,
+		"k4/5/5/5/KN3":   true,
+		"k4/5/5/5/KB3":   true,
+		"kb3/5/5/5/KB3":  true,
+		"k1b2/5/5/5/KB3": false,
+		"k4/5/5/5/KNN2":  false,
+		"k4/5/5/5/KP3":   false,
+		mateInOne:        false,
+	} {
+		got := hasInsufficientMaterial(decodeTestStorage(test, fen))
+		if got != want {
+			test.Fail()
+		}
+	}
+}
+
+func TestGamePlay_withDrawRules(test *testing.T) {
+	type args struct {
+		fen     string
+		players func(input string) Players
+		input   string
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantErr    error
+	}
+
+	humans := func(input string) Players {
+		reader := bufio.NewReader(strings.NewReader(input))
+		human := NewHumanPlayer(reader, ioutil.Discard)
+		return Players{models.White: human, models.Black: human}
+	}
+	scripted := func(input string) Players {
+		var whiteMoves, blackMoves []climodels.Move
+		moves := decodeTestMoves(test, strings.Fields(input)...)
+		for index, move := range moves {
+			if index%2 == 0 {
+				whiteMoves = append(whiteMoves, move)
+			} else {
+				blackMoves = append(blackMoves, move)
+			}
+		}
+
+		return Players{
+			models.White: NewScriptedPlayer(whiteMoves),
+			models.Black: NewScriptedPlayer(blackMoves),
+		}
+	}
+	for _, data := range []data{
+		{
+			args: args{
+				// the white king has no moves, but it isn't in check
+				fen:     "K4/2q2/1k3/5/5",
+				players: humans,
+				input:   "",
+			},
+			wantOutput: nil,
+			wantErr:    ErrStalemate,
+		},
+		{
+			args: args{
+				fen:     "k4/5/5/5/KB3",
+				players: humans,
+				input:   "",
+			},
+			wantOutput: nil,
+			wantErr:    ErrInsufficientMaterial,
+		},
+		{
+			args: args{
+				fen:     kingsWithRooks,
+				players: humans,
+				input:   threefoldMoves,
+			},
+			wantOutput: []string{"draw can be claimed: threefold repetition"},
+			wantErr:    io.EOF,
+		},
+		{
+			args: args{
+				fen:     kingsWithRooks,
+				players: humans,
+				input:   threefoldMoves + "claim\n",
+			},
+			wantOutput: nil,
+			wantErr:    ErrThreefoldRepetition,
+		},
+		{
+			args: args{
+				fen:     kingsWithRooks,
+				players: humans,
+				input:   "claim\n",
+			},
+			wantOutput: []string{"error: unable to claim a draw: no reason\n"},
+			wantErr:    io.EOF,
+		},
+		{
+			args: args{
+				fen: kingsWithRooks,
+				// a searcher claims a draw automatically
+				players: scripted,
+				input:   threefoldMoves,
+			},
+			wantOutput: nil,
+			wantErr:    ErrThreefoldRepetition,
+		},
+	} {
+		var output bytes.Buffer
+		game := NewGame(
+			&output,
+			newTestStorageEncoder(),
+			data.args.players(data.args.input),
+			decodeTestStorage(test, data.args.fen),
+		)
+		gotErr := game.Play(context.Background())
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if gotErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultMovesToGo   = 30
+	minimalMovesToGo   = 10
+	typicalMoveCount   = 20
+	minimalComplexity  = 0.5
+	maximalComplexity  = 1.5
+	hardDeadlineFactor = 3
+	// a part of a remaining time, which can be spent on a single move
+	maximalTimeShare = 0.5
+	// a forced move doesn't need thinking, but a search should finish
+	// at least its first iteration to get a move
+	forcedMoveComplexity = 0.01
+)
+
+// TimeState ...
+//
+// It describes a situation, in which a time for a move is allocated.
+type TimeState struct {
+	RemainingTime time.Duration
+	Increment     time.Duration
+	// a count of moves till a period end; zero means it's unknown
+	MovesToGo int
+	// a number of a current move starting from 1
+	MoveNumber int
+	// a count of correct moves in a current position
+	MoveCount int
+}
+
+// Deadlines ...
+//
+// They are counted from a move start. After a soft deadline, a search
+// should be finished as soon as possible without losing a result;
+// after a hard one, it should be interrupted.
+type Deadlines struct {
+	Soft time.Duration
+	Hard time.Duration
+}
+
+// TimeManager ...
+type TimeManager interface {
+	AllocateTime(state TimeState) Deadlines
+}
+
+// DefaultTimeManager ...
+//
+// It divides a remaining time among moves till a period end (or among
+// estimated moves till a game end) and corrects a result by a count
+// of correct moves.
+type DefaultTimeManager struct{}
+
+// AllocateTime ...
+func (manager DefaultTimeManager) AllocateTime(state TimeState) Deadlines {
+	movesToGo := state.MovesToGo
+	if movesToGo == 0 {
+		// a game end gets closer with each move
+		movesToGo = defaultMovesToGo - state.MoveNumber/2
+		if movesToGo < minimalMovesToGo {
+			movesToGo = minimalMovesToGo
+		}
+	}
+
+	complexity := float64(state.MoveCount) / typicalMoveCount
+	switch {
+	case state.MoveCount <= 1:
+		complexity = forcedMoveComplexity
+	case complexity < minimalComplexity:
+		complexity = minimalComplexity
+	case complexity > maximalComplexity:
+		complexity = maximalComplexity
+	}
+
+	baseTime := state.RemainingTime/time.Duration(movesToGo) + state.Increment
+	softDeadline := time.Duration(float64(baseTime) * complexity)
+	hardDeadline := hardDeadlineFactor * softDeadline
+
+	maximalTime := time.Duration(float64(state.RemainingTime) * maximalTimeShare)
+	if hardDeadline > maximalTime {
+		hardDeadline = maximalTime
+	}
+	if softDeadline > hardDeadline {
+		softDeadline = hardDeadline
+	}
+
+	return Deadlines{Soft: softDeadline, Hard: hardDeadline}
+}
+
+type softDeadlineKey struct{}
+
+func withDeadlines(
+	ctx context.Context,
+	startTime time.Time,
+	deadlines Deadlines,
+) (context.Context, context.CancelFunc) {
+	softDeadline := startTime.Add(deadlines.Soft)
+	ctx = context.WithValue(ctx, softDeadlineKey{}, softDeadline)
+	return context.WithDeadline(ctx, startTime.Add(deadlines.Hard))
+}
+
+func contextSoftDeadline(ctx context.Context) (deadline time.Time, ok bool) {
+	deadline, ok = ctx.Value(softDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDefaultTimeManagerAllocateTime(test *testing.T) {
+	type args struct {
+		state TimeState
+	}
+	type data struct {
+		args args
+		want Deadlines
+	}
+
+	for _, data := range []data{
+		{
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					MoveNumber:    1,
+					MoveCount:     20,
+				},
+			},
+			want: Deadlines{Soft: 10 * time.Second, Hard: 30 * time.Second},
+		},
+		{
+			// a game end is close
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					MoveNumber:    60,
+					MoveCount:     20,
+				},
+			},
+			want: Deadlines{Soft: 30 * time.Second, Hard: 90 * time.Second},
+		},
+		{
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					Increment:     2 * time.Second,
+					MovesToGo:     10,
+					MoveNumber:    1,
+					MoveCount:     20,
+				},
+			},
+			want: Deadlines{Soft: 32 * time.Second, Hard: 96 * time.Second},
+		},
+		{
+			// a simple position
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					MoveNumber:    1,
+					MoveCount:     5,
+				},
+			},
+			want: Deadlines{Soft: 5 * time.Second, Hard: 15 * time.Second},
+		},
+		{
+			// a complex position
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					MoveNumber:    1,
+					MoveCount:     50,
+				},
+			},
+			want: Deadlines{Soft: 15 * time.Second, Hard: 45 * time.Second},
+		},
+		{
+			// a forced move
+			args: args{
+				state: TimeState{
+					RemainingTime: 5 * time.Minute,
+					MoveNumber:    1,
+					MoveCount:     1,
+				},
+			},
+			want: Deadlines{
+				Soft: 100 * time.Millisecond,
+				Hard: 300 * time.Millisecond,
+			},
+		},
+		{
+			// a last move in a period
+			args: args{
+				state: TimeState{
+					RemainingTime: time.Minute,
+					MovesToGo:     1,
+					MoveNumber:    1,
+					MoveCount:     20,
+				},
+			},
+			want: Deadlines{Soft: 30 * time.Second, Hard: 30 * time.Second},
+		},
+	} {
+		got := DefaultTimeManager{}.AllocateTime(data.args.state)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestWithDeadlines(test *testing.T) {
+	startTime := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	ctx, cancel := withDeadlines(
+		context.Background(),
+		startTime,
+		Deadlines{Soft: time.Second, Hard: 3 * time.Second},
+	)
+	defer cancel()
+
+	softDeadline, ok := contextSoftDeadline(ctx)
+	if !ok || !softDeadline.Equal(startTime.Add(time.Second)) {
+		test.Fail()
+	}
+
+	hardDeadline, ok := ctx.Deadline()
+	if !ok || !hardDeadline.Equal(startTime.Add(3*time.Second)) {
+		test.Fail()
+	}
+
+	if _, ok := contextSoftDeadline(context.Background()); ok {
+		test.Fail()
+	}
+}
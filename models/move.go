@@ -0,0 +1,83 @@
+package models
+
+import (
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+// DefaultPromotion ...
+//
+// It's used when a kind of a promoted piece isn't chosen explicitly.
+const DefaultPromotion = models.Queen
+
+// Move ...
+//
+// It extends models.Move by a kind of a piece, which a pawn is promoted to.
+// The King kind (a zero value) means no promotion.
+type Move struct {
+	models.Move
+
+	Promotion models.Kind
+}
+
+// IsPromotion ...
+//
+// It checks that the move leads a pawn to the last rank.
+func IsPromotion(storage models.PieceStorage, move models.Move) bool {
+	piece, ok := storage.Piece(move.Start)
+	if !ok || piece.Kind() != models.Pawn {
+		return false
+	}
+
+	lastRank := 0
+	if piece.Color() == models.White {
+		lastRank = storage.Size().Height - 1
+	}
+
+	return move.Finish.Rank == lastRank
+}
+
+// IsPromotionKind ...
+//
+// It checks that a pawn can be promoted to a piece of the kind.
+func IsPromotionKind(kind models.Kind) bool {
+	switch kind {
+	case models.Queen, models.Rook, models.Bishop, models.Knight:
+		return true
+	}
+
+	return false
+}
+
+// WithDefaultPromotion ...
+//
+// It sets DefaultPromotion if the move is a promotion without a chosen kind.
+func (move Move) WithDefaultPromotion(storage models.PieceStorage) Move {
+	if move.Promotion == models.King && IsPromotion(storage, move.Move) {
+		move.Promotion = DefaultPromotion
+	}
+
+	return move
+}
+
+// Apply ...
+//
+// It applies the move to the storage and replaces a promoted pawn
+// by a piece of the chosen kind.
+func (move Move) Apply(storage models.PieceStorage) models.PieceStorage {
+	nextStorage := storage.ApplyMove(move.Move)
+	if move.Promotion == models.King {
+		return nextStorage
+	}
+
+	var nextPieces []models.Piece
+	for _, piece := range nextStorage.Pieces() {
+		if piece.Position() == move.Finish {
+			piece = pieces.NewPiece(move.Promotion, piece.Color(), move.Finish)
+		}
+
+		nextPieces = append(nextPieces, piece)
+	}
+
+	return models.NewBoard(nextStorage.Size(), nextPieces)
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"testing"
+
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+func decodeTestStorage(test *testing.T, fen string) models.PieceStorage {
+	storage, err := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return storage
+}
+
+func decodeTestMove(test *testing.T, text string) models.Move {
+	move, err := uci.DecodeMove(text)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return move
+}
+
+func TestIsPromotion(test *testing.T) {
+	type args struct {
+		fen  string
+		move string
+	}
+	type data struct {
+		args args
+		want bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{"3k1/P4/5/5/4K", "a4a5"},
+			want: true,
+		},
+		{
+			args: args{"3k1/5/5/p4/4K", "a2a1"},
+			want: true,
+		},
+		{
+			args: args{"3k1/5/P4/5/4K", "a3a4"},
+			want: false,
+		},
+		{
+			args: args{"3k1/P4/5/5/4K", "e1e2"},
+			want: false,
+		},
+		{
+			args: args{"3k1/P4/5/5/4K", "b1b2"},
+			want: false,
+		},
+	} {
+		got := IsPromotion(
+			decodeTestStorage(test, data.args.fen),
+			decodeTestMove(test, data.args.move),
+		)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestIsPromotionKind(test *testing.T) {
+	type args struct {
+		kind models.Kind
+	}
+	type data struct {
+		args args
+		want bool
+	}
+
+	for _, data := range []data{
+		{args{models.King}, false},
+		{args{models.Queen}, true},
+		{args{models.Rook}, true},
+		{args{models.Bishop}, true},
+		{args{models.Knight}, true},
+		{args{models.Pawn}, false},
+	} {
+		got := IsPromotionKind(data.args.kind)
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestMoveWithDefaultPromotion(test *testing.T) {
+	type fields struct {
+		move      string
+		promotion models.Kind
+	}
+	type data struct {
+		fields fields
+		want   models.Kind
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{"a4a5", models.King},
+			want:   DefaultPromotion,
+		},
+		{
+			fields: fields{"a4a5", models.Knight},
+			want:   models.Knight,
+		},
+		{
+			fields: fields{"e1e2", models.King},
+			want:   models.King,
+		},
+	} {
+		move := Move{
+			Move:      decodeTestMove(test, data.fields.move),
+			Promotion: data.fields.promotion,
+		}
+		got := move.WithDefaultPromotion(decodeTestStorage(test, "3k1/P4/5/5/4K"))
+
+		if got.Move != move.Move {
+			test.Fail()
+		}
+		if got.Promotion != data.want {
+			test.Fail()
+		}
+	}
+}
+
+func TestMoveApply(test *testing.T) {
+	type fields struct {
+		move      string
+		promotion models.Kind
+	}
+	type data struct {
+		fields fields
+		want   string
+	}
+
+	for _, data := range []data{
+		{
+			fields: fields{"a4a5", models.Knight},
+			want:   "N2k1/5/5/5/4K",
+		},
+		{
+			fields: fields{"e1e2", models.King},
+			want:   "3k1/P4/5/4K/5",
+		},
+	} {
+		move := Move{
+			Move:      decodeTestMove(test, data.fields.move),
+			Promotion: data.fields.promotion,
+		}
+		got := move.Apply(decodeTestStorage(test, "3k1/P4/5/5/4K"))
+
+		if uci.EncodePieceStorage(got) != data.want {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package protocols
+
+import (
+	"context"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+)
+
+// it's used when a search is limited by a time only
+const unlimitedDeep = 1000
+
+// it makes a terminator of a background search, which can be stopped
+// by the context; zero deep means a search limited by deadlines only,
+// nil deadlines mean a search limited by deep only
+func newTerminator(
+	ctx context.Context,
+	deep int,
+	deadlines *game.Deadlines,
+) terminators.SearchTerminator {
+	if deep == 0 {
+		deep = unlimitedDeep
+	}
+
+	group := []terminators.SearchTerminator{
+		terminators.NewDeepTerminator(deep),
+		game.NewContextTerminator(ctx),
+	}
+	if deadlines != nil {
+		group = append(group, game.NewDeadlineTerminator(
+			time.Now,
+			deadlines.Soft,
+			deadlines.Hard,
+		))
+	}
+
+	return terminators.NewGroupTerminator(group...)
+}
@@ -0,0 +1,62 @@
+package protocols
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+)
+
+func TestNewTerminator(test *testing.T) {
+	type args struct {
+		deep        int
+		deadlines   *game.Deadlines
+		isStopped   bool
+		deepToCheck int
+	}
+	type data struct {
+		args args
+		want bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{deep: 2, deepToCheck: 1},
+			want: false,
+		},
+		{
+			args: args{deep: 2, deepToCheck: 3},
+			want: true,
+		},
+		{
+			args: args{deep: 0, deepToCheck: 100},
+			want: false,
+		},
+		{
+			args: args{deep: 0, isStopped: true, deepToCheck: 1},
+			want: true,
+		},
+		{
+			args: args{
+				deep:        0,
+				deadlines:   &game.Deadlines{Soft: -time.Second, Hard: -time.Second},
+				deepToCheck: 1,
+			},
+			want: true,
+		},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		if data.args.isStopped {
+			cancel()
+		}
+
+		terminator := newTerminator(ctx, data.args.deep, data.args.deadlines)
+		got := terminator.IsSearchTerminated(data.args.deepToCheck)
+		cancel()
+
+		if got != data.want {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package protocols
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	"github.com/thewizardplusplus/go-chess-minimax/terminators"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+const (
+	engineName   = "go-chess-cli"
+	engineAuthor = "thewizardplusplus"
+	nullMove     = "0000"
+)
+
+// UCIEngine ...
+//
+// It communicates by the Universal Chess Interface. A search is performed
+// in background, so commands are processed during it.
+type UCIEngine struct {
+	reader         io.Reader
+	writer         io.Writer
+	writerLocker   sync.Mutex
+	settings       game.SearchSettings
+	timeManager    game.TimeManager
+	initialStorage models.PieceStorage
+	position       position
+	stopSearch     context.CancelFunc
+	searchDone     chan struct{}
+}
+
+type position struct {
+	storage    models.PieceStorage
+	color      models.Color
+	moveNumber int
+}
+
+type searchLimits struct {
+	deep          int
+	moveTime      time.Duration
+	remainingTime map[models.Color]time.Duration
+	increment     map[models.Color]time.Duration
+	movesToGo     int
+	isInfinite    bool
+}
+
+// NewUCIEngine ...
+//
+// The initial storage is used by the "position startpos" command instead
+// of the standard initial position (e.g. for minichess variants).
+// The settings limit a search, which isn't limited by a "go" command.
+func NewUCIEngine(
+	reader io.Reader,
+	writer io.Writer,
+	initialStorage models.PieceStorage,
+	settings game.SearchSettings,
+) *UCIEngine {
+	engine := &UCIEngine{
+		reader:         reader,
+		writer:         writer,
+		settings:       settings,
+		timeManager:    game.DefaultTimeManager{},
+		initialStorage: initialStorage,
+	}
+	engine.resetPosition()
+
+	return engine
+}
+
+// SetTimeManager ...
+//
+// It sets a strategy of allocating a time by the "wtime" and "btime"
+// parameters of a "go" command (by default: game.DefaultTimeManager).
+func (engine *UCIEngine) SetTimeManager(timeManager game.TimeManager) {
+	engine.timeManager = timeManager
+}
+
+// Run ...
+//
+// It processes commands till the "quit" command or the input end.
+// Errors of commands are reported by "info string" lines.
+func (engine *UCIEngine) Run() error {
+	defer engine.finishSearch()
+
+	scanner := bufio.NewScanner(engine.reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "quit" {
+			return nil
+		}
+
+		if err := engine.executeCommand(fields[0], fields[1:]); err != nil {
+			engine.writeLine("info string error: %s", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read the command: %s", err)
+	}
+
+	// nothing can stop an infinite search after the input end,
+	// so the search is stopped by the deferred call
+	return nil
+}
+
+func (engine *UCIEngine) executeCommand(
+	name string,
+	arguments []string,
+) error {
+	switch name {
+	case "uci":
+		engine.writeLine("id name %s", engineName)
+		engine.writeLine("id author %s", engineAuthor)
+		engine.writeLine("uciok")
+	case "isready":
+		engine.writeLine("readyok")
+	case "ucinewgame":
+		engine.finishSearch()
+		engine.resetPosition()
+	case "position":
+		engine.finishSearch()
+		return engine.setPosition(arguments)
+	case "go":
+		engine.finishSearch()
+
+		limits, err := parseSearchLimits(arguments)
+		if err != nil {
+			return err // don't wrap
+		}
+
+		return engine.startSearch(limits)
+	case "stop":
+		engine.finishSearch()
+	default:
+		// unknown commands should be ignored by the protocol
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	return nil
+}
+
+func (engine *UCIEngine) resetPosition() {
+	engine.position = position{
+		storage:    engine.initialStorage,
+		color:      models.White,
+		moveNumber: 1,
+	}
+}
+
+func (engine *UCIEngine) setPosition(arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New("no position")
+	}
+
+	var newPosition position
+	var moves []string
+	switch arguments[0] {
+	case "startpos":
+		newPosition = position{
+			storage:    engine.initialStorage,
+			color:      models.White,
+			moveNumber: 1,
+		}
+		moves = arguments[1:]
+	case "fen":
+		index := len(arguments)
+		for argumentIndex, argument := range arguments {
+			if argument == "moves" {
+				index = argumentIndex
+				break
+			}
+		}
+
+		var err error
+		newPosition, err = decodePosition(arguments[1:index])
+		if err != nil {
+			return fmt.Errorf("unable to decode the position: %s", err)
+		}
+
+		moves = arguments[index:]
+	default:
+		return fmt.Errorf("unknown position: %s", arguments[0])
+	}
+	if len(moves) != 0 {
+		if moves[0] != "moves" {
+			return fmt.Errorf("unknown argument: %s", moves[0])
+		}
+
+		moves = moves[1:]
+	}
+
+	for index, text := range moves {
+		move, err :=
+			game.DecodeMove(newPosition.storage, newPosition.color, text)
+		if err == nil {
+			err = newPosition.storage.CheckMove(move.Move)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to apply the move #%d: %s", index+1, err)
+		}
+
+		move = move.WithDefaultPromotion(newPosition.storage)
+		newPosition.storage = move.Apply(newPosition.storage)
+		if newPosition.color == models.Black {
+			newPosition.moveNumber++
+		}
+		newPosition.color = newPosition.color.Negative()
+	}
+
+	engine.position = newPosition
+	return nil
+}
+
+func (engine *UCIEngine) startSearch(limits searchLimits) error {
+	currentPosition := engine.position
+	moves, err :=
+		san.CorrectMoves(currentPosition.storage, currentPosition.color)
+	if err != nil {
+		return fmt.Errorf("unable to generate moves: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	terminator := engine.makeTerminator(ctx, limits, len(moves))
+	searchDone := make(chan struct{})
+	engine.stopSearch, engine.searchDone = cancel, searchDone
+
+	go func() {
+		defer close(searchDone)
+
+		move, err := game.Search(
+			engine.settings.Cache,
+			currentPosition.storage,
+			currentPosition.color,
+			terminator,
+		)
+		if err == nil {
+			move, err = game.EnsureMove(
+				engine.settings.Cache,
+				currentPosition.storage,
+				currentPosition.color,
+				move,
+			)
+		}
+
+		// a null move is sent only if there are no correct moves
+		text := nullMove
+		if err == nil {
+			fullMove := climodels.Move{Move: move.Move}
+			fullMove = fullMove.WithDefaultPromotion(currentPosition.storage)
+			text, _ = game.EncodeUCIMove( // nolint: gosec
+				currentPosition.storage,
+				fullMove,
+			)
+		}
+
+		engine.writeLine("bestmove %s", text)
+	}()
+
+	return nil
+}
+
+func (engine *UCIEngine) makeTerminator(
+	ctx context.Context,
+	limits searchLimits,
+	moveCount int,
+) terminators.SearchTerminator {
+	color := engine.position.color
+	deep := limits.deep
+	var deadlines *game.Deadlines
+	switch {
+	case limits.moveTime != 0:
+		deadlines = &game.Deadlines{Soft: limits.moveTime, Hard: limits.moveTime}
+	case limits.remainingTime[color] != 0:
+		allocatedDeadlines := engine.timeManager.AllocateTime(game.TimeState{
+			RemainingTime: limits.remainingTime[color],
+			Increment:     limits.increment[color],
+			MovesToGo:     limits.movesToGo,
+			MoveNumber:    engine.position.moveNumber,
+			MoveCount:     moveCount,
+		})
+		deadlines = &allocatedDeadlines
+	case !limits.isInfinite && deep == 0:
+		deadlines = &game.Deadlines{
+			Soft: engine.settings.Duration,
+			Hard: engine.settings.Duration,
+		}
+		deep = engine.settings.Deep
+	}
+
+	return newTerminator(ctx, deep, deadlines)
+}
+
+func (engine *UCIEngine) finishSearch() {
+	if engine.stopSearch == nil {
+		return
+	}
+
+	engine.stopSearch()
+	engine.waitSearch()
+}
+
+func (engine *UCIEngine) waitSearch() {
+	if engine.searchDone == nil {
+		return
+	}
+
+	<-engine.searchDone
+	engine.stopSearch()
+	engine.stopSearch, engine.searchDone = nil, nil
+}
+
+func (engine *UCIEngine) writeLine(format string, arguments ...interface{}) {
+	engine.writerLocker.Lock()
+	defer engine.writerLocker.Unlock()
+
+	fmt.Fprintf(engine.writer, format+"\n", arguments...) // nolint: errcheck
+}
+
+func decodePosition(fields []string) (position, error) {
+	if len(fields) == 0 {
+		return position{}, errors.New("no FEN")
+	}
+
+	storage, err := uci.DecodePieceStorage(
+		fields[0],
+		pieces.NewPiece,
+		models.NewBoard,
+	)
+	if err != nil {
+		return position{}, err // don't wrap
+	}
+
+	color := models.White
+	if len(fields) > 1 && fields[1] == "b" {
+		color = models.Black
+	}
+
+	moveNumber := 1
+	if len(fields) > 5 {
+		moveNumber, err = strconv.Atoi(fields[5])
+		if err != nil {
+			return position{}, fmt.Errorf("incorrect move number: %s", err)
+		}
+	}
+
+	return position{storage, color, moveNumber}, nil
+}
+
+func parseSearchLimits(arguments []string) (searchLimits, error) {
+	limits := searchLimits{
+		remainingTime: make(map[models.Color]time.Duration),
+		increment:     make(map[models.Color]time.Duration),
+	}
+	for index := 0; index < len(arguments); index++ {
+		name := arguments[index]
+		if name == "infinite" {
+			limits.isInfinite = true
+			continue
+		}
+
+		var value int
+		switch name {
+		case "depth", "movetime", "wtime", "btime", "winc", "binc", "movestogo":
+			if index+1 == len(arguments) {
+				return searchLimits{}, fmt.Errorf("no value of %s", name)
+			}
+
+			var err error
+			index++
+			value, err = strconv.Atoi(arguments[index])
+			if err != nil {
+				return searchLimits{}, fmt.Errorf("incorrect %s: %s", name, err)
+			}
+		default:
+			// other parameters aren't supported and are ignored
+			continue
+		}
+
+		milliseconds := time.Duration(value) * time.Millisecond
+		switch name {
+		case "depth":
+			limits.deep = value
+		case "movetime":
+			limits.moveTime = milliseconds
+		case "wtime":
+			limits.remainingTime[models.White] = milliseconds
+		case "btime":
+			limits.remainingTime[models.Black] = milliseconds
+		case "winc":
+			limits.increment[models.White] = milliseconds
+		case "binc":
+			limits.increment[models.Black] = milliseconds
+		case "movestogo":
+			limits.movesToGo = value
+		}
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,190 @@
+package protocols
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+	"github.com/thewizardplusplus/go-chess-models/pieces"
+)
+
+const (
+	// white mates in one, e.g. by b1b5
+	mateInOne = "k4/2K2/5/5/1Q3"
+)
+
+func decodeTestStorage(test *testing.T, fen string) models.PieceStorage {
+	storage, err := uci.DecodePieceStorage(fen, pieces.NewPiece, models.NewBoard)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return storage
+}
+
+func newTestSearchSettings() game.SearchSettings {
+	return game.SearchSettings{
+		Deep:     2,
+		Duration: time.Second,
+	}
+}
+
+func TestUCIEngineRun(test *testing.T) {
+	type args struct {
+		input string
+	}
+	type data struct {
+		args          args
+		wantOutput    []string
+		notWantOutput []string
+	}
+
+	for _, data := range []data{
+		{
+			args: args{"uci\nisready\nquit\n"},
+			wantOutput: []string{
+				"id name go-chess-cli\n",
+				"id author thewizardplusplus\n",
+				"uciok\n",
+				"readyok\n",
+			},
+		},
+		{
+			args:          args{"position startpos\ngo depth 2\n"},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			args: args{
+				"position fen " + mateInOne + " w - - 0 1\ngo movetime 100\n",
+			},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			args: args{
+				"position fen " + mateInOne + " w - - 0 1 moves c4b3 a5b5\n" +
+					"go wtime 1000 btime 1000\n",
+			},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			args:       args{"position startpos moves b1b5\ngo\n"},
+			wantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			args: args{"position startpos moves b1b6\nunknown\n"},
+			wantOutput: []string{
+				"info string error: unable to apply the move #1: ",
+				"info string error: unknown command: unknown\n",
+			},
+		},
+		{
+			// the search is stopped before its first iteration
+			args:          args{"go movetime 1\n"},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			args:          args{"go infinite\nstop\nquit\n"},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+		{
+			// the search is stopped by the input end
+			args:          args{"go infinite\n"},
+			wantOutput:    []string{"bestmove "},
+			notWantOutput: []string{"bestmove 0000\n"},
+		},
+	} {
+		var output bytes.Buffer
+		engine := NewUCIEngine(
+			strings.NewReader(data.args.input),
+			&output,
+			decodeTestStorage(test, mateInOne),
+			newTestSearchSettings(),
+		)
+		gotErr := engine.Run()
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		for _, line := range data.notWantOutput {
+			if strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if gotErr != nil {
+			test.Fail()
+		}
+	}
+}
+
+func TestParseSearchLimits(test *testing.T) {
+	type args struct {
+		arguments []string
+	}
+	type data struct {
+		args       args
+		wantLimits searchLimits
+		wantErr    bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{[]string{"depth", "3", "infinite", "ponder"}},
+			wantLimits: searchLimits{
+				deep:          3,
+				remainingTime: map[models.Color]time.Duration{},
+				increment:     map[models.Color]time.Duration{},
+				isInfinite:    true,
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				[]string{
+					"wtime", "1000", "btime", "2000", "winc", "10", "movestogo", "5",
+				},
+			},
+			wantLimits: searchLimits{
+				remainingTime: map[models.Color]time.Duration{
+					models.White: time.Second,
+					models.Black: 2 * time.Second,
+				},
+				increment: map[models.Color]time.Duration{
+					models.White: 10 * time.Millisecond,
+				},
+				movesToGo: 5,
+			},
+			wantErr: false,
+		},
+		{
+			args:       args{[]string{"movetime"}},
+			wantLimits: searchLimits{},
+			wantErr:    true,
+		},
+		{
+			args:       args{[]string{"depth", "incorrect"}},
+			wantLimits: searchLimits{},
+			wantErr:    true,
+		},
+	} {
+		gotLimits, gotErr := parseSearchLimits(data.args.arguments)
+
+		if !reflect.DeepEqual(gotLimits, data.wantLimits) {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
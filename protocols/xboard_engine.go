@@ -0,0 +1,503 @@
+package protocols
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	"github.com/thewizardplusplus/go-chess-cli/game"
+	climodels "github.com/thewizardplusplus/go-chess-cli/models"
+	minimax "github.com/thewizardplusplus/go-chess-minimax"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// nolint: gochecknoglobals
+var xboardFeatures = []string{
+	"myname=\"" + engineName + "\"",
+	"setboard=1",
+	"usermove=1",
+	"ping=0",
+	"time=0",
+	"sigint=0",
+	"sigterm=0",
+	"colors=0",
+	"done=1",
+}
+
+// XBoardEngine ...
+//
+// It communicates by the Chess Engine Communication Protocol. A search is
+// performed in background, so commands are processed during it.
+type XBoardEngine struct {
+	reader         io.Reader
+	writer         io.Writer
+	settings       game.SearchSettings
+	timeManager    game.TimeManager
+	initialStorage models.PieceStorage
+	positions      []position
+	engineColor    models.Color
+	isForced       bool
+	deep           int
+	moveTime       time.Duration
+	clock          *game.Clock
+	stopSearch     context.CancelFunc
+	searchResults  chan searchResult
+}
+
+type searchResult struct {
+	move climodels.Move
+	err  error
+}
+
+// NewXBoardEngine ...
+//
+// The initial storage is used by the "new" command.
+// The settings limit a search, which isn't limited by the "level", "st"
+// and "sd" commands.
+func NewXBoardEngine(
+	reader io.Reader,
+	writer io.Writer,
+	initialStorage models.PieceStorage,
+	settings game.SearchSettings,
+) *XBoardEngine {
+	engine := &XBoardEngine{
+		reader:         reader,
+		writer:         writer,
+		settings:       settings,
+		timeManager:    game.DefaultTimeManager{},
+		initialStorage: initialStorage,
+	}
+	engine.resetGame()
+
+	return engine
+}
+
+// SetTimeManager ...
+//
+// It sets a strategy of allocating a time by the "level" command
+// (by default: game.DefaultTimeManager).
+func (engine *XBoardEngine) SetTimeManager(timeManager game.TimeManager) {
+	engine.timeManager = timeManager
+}
+
+// Run ...
+//
+// It processes commands till the "quit" command or the input end.
+// Errors of commands are reported by "Error" lines.
+func (engine *XBoardEngine) Run() error {
+	defer engine.cancelSearch()
+
+	lines, readingErrs := readLines(engine.reader)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if err := <-readingErrs; err != nil {
+					return fmt.Errorf("unable to read the command: %s", err)
+				}
+
+				// nothing can stop a search without limits after the input end
+				engine.finishSearch()
+				return nil
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+
+			if fields[0] == "quit" {
+				return nil
+			}
+
+			if err := engine.executeCommand(fields[0], fields[1:]); err != nil {
+				engine.writeLine("Error (%s): %s", err, line)
+			}
+		case result := <-engine.searchResults:
+			engine.makeMove(engine.takeSearchResult(result))
+		}
+	}
+}
+
+func (engine *XBoardEngine) executeCommand(
+	name string,
+	arguments []string,
+) error {
+	switch name {
+	case "xboard", "accepted", "rejected", "random", "post", "nopost", "hard",
+		"easy", "computer", "name", "rating", "ics":
+		// these commands don't affect the engine
+	case "protover":
+		engine.writeLine("feature %s", strings.Join(xboardFeatures, " "))
+	case "new":
+		engine.cancelSearch()
+		engine.resetGame()
+	case "setboard":
+		engine.cancelSearch()
+
+		newPosition, err := decodePosition(arguments)
+		if err != nil {
+			return fmt.Errorf("illegal position: %s", err)
+		}
+
+		engine.positions = []position{newPosition}
+	case "usermove":
+		if len(arguments) == 0 {
+			return errors.New("no move")
+		}
+
+		return engine.applyUserMove(arguments[0])
+	case "go":
+		engine.cancelSearch()
+		engine.isForced = false
+		engine.engineColor = engine.position().color
+
+		return engine.startSearch()
+	case "?":
+		engine.finishSearch()
+	case "force":
+		engine.cancelSearch()
+		engine.isForced = true
+	case "result":
+		engine.cancelSearch()
+		engine.isForced = true
+	case "level":
+		timeControl, err := parseTimeControl(arguments)
+		if err != nil {
+			return err // don't wrap
+		}
+
+		engine.clock = game.NewClock(timeControl, time.Now)
+		engine.moveTime = 0
+	case "st":
+		if len(arguments) == 0 {
+			return errors.New("no time")
+		}
+
+		moveTime, err := parseSeconds(arguments[0])
+		if err != nil {
+			return fmt.Errorf("incorrect time: %s", err)
+		}
+
+		engine.moveTime, engine.clock = moveTime, nil
+	case "sd":
+		if len(arguments) == 0 {
+			return errors.New("no deep")
+		}
+
+		deep, err := strconv.Atoi(arguments[0])
+		if err != nil || deep < 0 {
+			return errors.New("incorrect deep")
+		}
+
+		engine.deep = deep
+	case "undo":
+		engine.cancelSearch()
+		return engine.takeBack(1)
+	case "remove":
+		engine.cancelSearch()
+		return engine.takeBack(2)
+	default:
+		// the first version of the protocol sends moves without a command
+		currentPosition := engine.position()
+		_, err :=
+			game.DecodeMove(currentPosition.storage, currentPosition.color, name)
+		if err != nil {
+			return errors.New("unknown command")
+		}
+
+		return engine.applyUserMove(name)
+	}
+
+	return nil
+}
+
+func (engine *XBoardEngine) resetGame() {
+	engine.positions = []position{
+		{storage: engine.initialStorage, color: models.White, moveNumber: 1},
+	}
+	engine.engineColor = models.Black
+	engine.isForced = false
+	engine.deep = 0
+	if engine.clock != nil {
+		engine.clock.Reset()
+	}
+}
+
+func (engine *XBoardEngine) position() position {
+	return engine.positions[len(engine.positions)-1]
+}
+
+func (engine *XBoardEngine) pushMove(move climodels.Move) position {
+	currentPosition := engine.position()
+	nextPosition := position{
+		storage:    move.Apply(currentPosition.storage),
+		color:      currentPosition.color.Negative(),
+		moveNumber: currentPosition.moveNumber,
+	}
+	if currentPosition.color == models.Black {
+		nextPosition.moveNumber++
+	}
+
+	engine.positions = append(engine.positions, nextPosition)
+	return nextPosition
+}
+
+func (engine *XBoardEngine) takeBack(plyCount int) error {
+	if len(engine.positions) <= plyCount {
+		return errors.New("cannot undo")
+	}
+
+	engine.positions = engine.positions[:len(engine.positions)-plyCount]
+	return nil
+}
+
+func (engine *XBoardEngine) applyUserMove(text string) error {
+	if engine.stopSearch != nil {
+		return errors.New("engine is thinking")
+	}
+
+	currentPosition := engine.position()
+	move, err :=
+		game.DecodeMove(currentPosition.storage, currentPosition.color, text)
+	if err == nil {
+		move = move.WithDefaultPromotion(currentPosition.storage)
+		err = game.CheckMove(currentPosition.storage, currentPosition.color, move)
+	}
+	if err != nil {
+		// it's the required format of a reply to an incorrect move
+		engine.writeLine("Illegal move: %s", text)
+		return nil
+	}
+
+	nextPosition := engine.pushMove(move)
+	if engine.isForced || nextPosition.color != engine.engineColor {
+		return nil
+	}
+
+	return engine.startSearch()
+}
+
+func (engine *XBoardEngine) startSearch() error {
+	currentPosition := engine.position()
+	moves, err :=
+		san.CorrectMoves(currentPosition.storage, currentPosition.color)
+	if err != nil {
+		return fmt.Errorf("unable to generate moves: %s", err)
+	}
+
+	deep := engine.deep
+	var deadlines *game.Deadlines
+	switch {
+	case engine.moveTime != 0:
+		deadlines = &game.Deadlines{Soft: engine.moveTime, Hard: engine.moveTime}
+	case engine.clock != nil:
+		color := currentPosition.color
+		engine.clock.Start(color)
+
+		allocatedDeadlines := engine.timeManager.AllocateTime(game.TimeState{
+			RemainingTime: engine.clock.RemainingTime(color),
+			Increment:     engine.clock.TimeControl().Increment,
+			MovesToGo:     engine.clock.MovesToGo(color),
+			MoveNumber:    currentPosition.moveNumber,
+			MoveCount:     len(moves),
+		})
+		deadlines = &allocatedDeadlines
+	default:
+		deadlines = &game.Deadlines{
+			Soft: engine.settings.Duration,
+			Hard: engine.settings.Duration,
+		}
+		if deep == 0 {
+			deep = engine.settings.Deep
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	terminator := newTerminator(ctx, deep, deadlines)
+	searchResults := make(chan searchResult, 1)
+	engine.stopSearch, engine.searchResults = cancel, searchResults
+
+	go func() {
+		move, err := game.Search(
+			engine.settings.Cache,
+			currentPosition.storage,
+			currentPosition.color,
+			terminator,
+		)
+		if err == nil {
+			move, err = game.EnsureMove(
+				engine.settings.Cache,
+				currentPosition.storage,
+				currentPosition.color,
+				move,
+			)
+		}
+
+		fullMove := climodels.Move{Move: move.Move}
+		fullMove = fullMove.WithDefaultPromotion(currentPosition.storage)
+		searchResults <- searchResult{fullMove, err}
+	}()
+
+	return nil
+}
+
+// it stops a search and makes a found move
+func (engine *XBoardEngine) finishSearch() {
+	if engine.stopSearch == nil {
+		return
+	}
+
+	engine.stopSearch()
+	engine.waitSearch()
+}
+
+// it waits for a search and makes a found move
+func (engine *XBoardEngine) waitSearch() {
+	if engine.stopSearch == nil {
+		return
+	}
+
+	engine.makeMove(engine.takeSearchResult(<-engine.searchResults))
+}
+
+// it stops a search and discards a found move
+func (engine *XBoardEngine) cancelSearch() {
+	if engine.stopSearch == nil {
+		return
+	}
+
+	engine.stopSearch()
+	engine.takeSearchResult(<-engine.searchResults)
+}
+
+func (engine *XBoardEngine) takeSearchResult(
+	result searchResult,
+) searchResult {
+	engine.stopSearch()
+	engine.stopSearch, engine.searchResults = nil, nil
+
+	return result
+}
+
+func (engine *XBoardEngine) makeMove(result searchResult) {
+	currentPosition := engine.position()
+	if result.err != nil {
+		engine.writeResult(result.err, currentPosition.color)
+		return
+	}
+
+	text, err := game.EncodeUCIMove(currentPosition.storage, result.move)
+	if err != nil {
+		engine.writeLine("tellusererror unable to encode the move: %s", err)
+		return
+	}
+
+	if engine.clock != nil {
+		engine.clock.Stop() // nolint: errcheck, gosec
+	}
+
+	nextPosition := engine.pushMove(result.move)
+	engine.writeLine("move %s", text)
+
+	err = game.Check(nextPosition.storage, nextPosition.color)
+	if err != nil {
+		engine.writeResult(err, nextPosition.color)
+	}
+}
+
+func (engine *XBoardEngine) writeResult(state error, color models.Color) {
+	switch state {
+	case minimax.ErrCheckmate:
+		if color == models.White {
+			engine.writeLine("0-1 {Black mates}")
+		} else {
+			engine.writeLine("1-0 {White mates}")
+		}
+	case minimax.ErrDraw:
+		engine.writeLine("1/2-1/2 {Draw}")
+	default:
+		engine.writeLine("tellusererror unable to search the move: %s", state)
+	}
+}
+
+func (engine *XBoardEngine) writeLine(
+	format string,
+	arguments ...interface{},
+) {
+	fmt.Fprintf(engine.writer, format+"\n", arguments...) // nolint: errcheck
+}
+
+func readLines(reader io.Reader) (lines <-chan string, errs <-chan error) {
+	lineChannel := make(chan string)
+	errChannel := make(chan error, 1)
+	go func() {
+		defer close(lineChannel)
+
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			lineChannel <- scanner.Text()
+		}
+
+		errChannel <- scanner.Err()
+	}()
+
+	return lineChannel, errChannel
+}
+
+func parseTimeControl(arguments []string) (game.TimeControl, error) {
+	if len(arguments) != 3 {
+		return game.TimeControl{}, errors.New("incorrect time control")
+	}
+
+	movesPerPeriod, err := strconv.Atoi(arguments[0])
+	if err != nil || movesPerPeriod < 0 {
+		return game.TimeControl{}, errors.New("incorrect moves per period")
+	}
+
+	// a base time is specified in minutes or as minutes:seconds
+	minutes, seconds := arguments[1], "0"
+	if index := strings.IndexByte(minutes, ':'); index != -1 {
+		minutes, seconds = minutes[:index], minutes[index+1:]
+	}
+
+	baseMinutes, err := strconv.Atoi(minutes)
+	if err != nil || baseMinutes < 0 {
+		return game.TimeControl{}, errors.New("incorrect base time")
+	}
+
+	baseSeconds, err := parseSeconds(seconds)
+	if err != nil {
+		return game.TimeControl{}, errors.New("incorrect base time")
+	}
+
+	increment, err := parseSeconds(arguments[2])
+	if err != nil {
+		return game.TimeControl{}, errors.New("incorrect increment")
+	}
+
+	return game.TimeControl{
+		Base:           time.Duration(baseMinutes)*time.Minute + baseSeconds,
+		MovesPerPeriod: movesPerPeriod,
+		Increment:      increment,
+		IncrementMode:  game.FischerIncrement,
+	}, nil
+}
+
+func parseSeconds(text string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, err // don't wrap
+	}
+	if seconds < 0 {
+		return 0, errors.New("negative time")
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
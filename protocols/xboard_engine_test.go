@@ -0,0 +1,179 @@
+package protocols
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thewizardplusplus/go-chess-cli/game"
+)
+
+func TestXBoardEngineRun(test *testing.T) {
+	type args struct {
+		input string
+	}
+	type data struct {
+		args          args
+		wantOutput    []string
+		notWantOutput []string
+	}
+
+	setboard := "setboard " + mateInOne + " w - - 0 1\n"
+	for _, data := range []data{
+		{
+			args: args{"xboard\nprotover 2\naccepted done\nquit\n"},
+			wantOutput: []string{
+				"feature myname=\"go-chess-cli\" setboard=1 usermove=1 ",
+				" done=1\n",
+			},
+			notWantOutput: []string{"Error"},
+		},
+		{
+			args:          args{"new\n" + setboard + "usermove b1b6\n"},
+			wantOutput:    []string{"Illegal move: b1b6\n"},
+			notWantOutput: []string{"move b1b6\n"},
+		},
+		{
+			args:          args{setboard + "force\nusermove b1b5\n"},
+			notWantOutput: []string{"move ", "Error", "{White mates}"},
+		},
+		{
+			args:       args{setboard + "usermove c4b3\n"},
+			wantOutput: []string{"move "},
+		},
+		{
+			args:       args{setboard + "force\nc4b3\ngo\n"},
+			wantOutput: []string{"move "},
+		},
+		{
+			args:       args{setboard + "force\nusermove b1b5\ngo\n"},
+			wantOutput: []string{"1-0 {White mates}\n"},
+		},
+		{
+			args: args{
+				setboard + "force\nusermove c4b3\nundo\nusermove b1b5\ngo\n",
+			},
+			wantOutput:    []string{"1-0 {White mates}\n"},
+			notWantOutput: []string{"Error"},
+		},
+		{
+			args:       args{setboard + "remove\n"},
+			wantOutput: []string{"Error (cannot undo): remove\n"},
+		},
+		{
+			args:          args{setboard + "sd 2\ngo\nresult 1-0 {White mates}\n"},
+			notWantOutput: []string{"Error"},
+		},
+		{
+			args:       args{setboard + "st 0.1\ngo\n"},
+			wantOutput: []string{"move "},
+		},
+		{
+			args:       args{setboard + "level 0 0:30 1\ngo\n"},
+			wantOutput: []string{"move "},
+		},
+		{
+			// the search is stopped before its first iteration
+			args:          args{setboard + "go\n?\n"},
+			wantOutput:    []string{"move "},
+			notWantOutput: []string{"tellusererror"},
+		},
+		{
+			args:          args{setboard + "st 0.001\ngo\n"},
+			wantOutput:    []string{"move "},
+			notWantOutput: []string{"tellusererror"},
+		},
+		{
+			args: args{"level 40 x 0\nst\nunknown\n"},
+			wantOutput: []string{
+				"Error (incorrect base time): level 40 x 0\n",
+				"Error (no time): st\n",
+				"Error (unknown command): unknown\n",
+			},
+		},
+	} {
+		var output bytes.Buffer
+		engine := NewXBoardEngine(
+			strings.NewReader(data.args.input),
+			&output,
+			decodeTestStorage(test, mateInOne),
+			newTestSearchSettings(),
+		)
+		gotErr := engine.Run()
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		for _, line := range data.notWantOutput {
+			if strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if gotErr != nil {
+			test.Fail()
+		}
+	}
+}
+
+func TestParseTimeControl(test *testing.T) {
+	type args struct {
+		arguments []string
+	}
+	type data struct {
+		args            args
+		wantTimeControl game.TimeControl
+		wantErr         bool
+	}
+
+	for _, data := range []data{
+		{
+			args: args{[]string{"40", "5", "0"}},
+			wantTimeControl: game.TimeControl{
+				Base:           5 * time.Minute,
+				MovesPerPeriod: 40,
+			},
+			wantErr: false,
+		},
+		{
+			args: args{[]string{"0", "2:30", "1.5"}},
+			wantTimeControl: game.TimeControl{
+				Base:      2*time.Minute + 30*time.Second,
+				Increment: 1500 * time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			args:            args{[]string{"40", "5"}},
+			wantTimeControl: game.TimeControl{},
+			wantErr:         true,
+		},
+		{
+			args:            args{[]string{"-1", "5", "0"}},
+			wantTimeControl: game.TimeControl{},
+			wantErr:         true,
+		},
+		{
+			args:            args{[]string{"0", "5:x", "0"}},
+			wantTimeControl: game.TimeControl{},
+			wantErr:         true,
+		},
+		{
+			args:            args{[]string{"0", "5", "-1"}},
+			wantTimeControl: game.TimeControl{},
+			wantErr:         true,
+		},
+	} {
+		gotTimeControl, gotErr := parseTimeControl(data.args.arguments)
+
+		if !reflect.DeepEqual(gotTimeControl, data.wantTimeControl) {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package viewer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/san"
+	models "github.com/thewizardplusplus/go-chess-models"
+)
+
+// nolint: gochecknoglobals
+var (
+	commandDescriptions = map[string]string{
+		"help":  "show this help message",
+		"quit":  "quit the viewer",
+		"next":  "go to the next move (also: n or an empty line)",
+		"back":  "go to the previous move (also: b)",
+		"first": "go to the initial position",
+		"last":  "go to the last move",
+		"jump":  "go to the position after the specified ply (e.g. jump 4)",
+		"game":  "open the game with the specified number (e.g. game 2)",
+		"games": "list the games",
+	}
+	commandAliases = map[string]string{
+		"":  "next",
+		"n": "next",
+		"b": "back",
+	}
+)
+
+var errQuit = errors.New("quit")
+
+// Viewer ...
+//
+// It replays games move by move on user commands.
+type Viewer struct {
+	reader         *bufio.Reader
+	writer         io.Writer
+	storageEncoder ascii.PieceStorageEncoder
+	games          []pgn.Game
+	gameIndex      int
+	ply            int
+}
+
+// NewViewer ...
+//
+// It opens the first game in the initial position.
+func NewViewer(
+	reader *bufio.Reader,
+	writer io.Writer,
+	storageEncoder ascii.PieceStorageEncoder,
+	games []pgn.Game,
+) *Viewer {
+	return &Viewer{
+		reader:         reader,
+		writer:         writer,
+		storageEncoder: storageEncoder,
+		games:          games,
+	}
+}
+
+// Game ...
+//
+// It returns a number of the opened game starting from 1.
+func (viewer *Viewer) Game() int {
+	return viewer.gameIndex + 1
+}
+
+// Ply ...
+//
+// It returns a count of applied moves of the opened game.
+func (viewer *Viewer) Ply() int {
+	return viewer.ply
+}
+
+// Storage ...
+//
+// It returns a storage after applied moves of the opened game.
+func (viewer *Viewer) Storage() models.PieceStorage {
+	game := viewer.games[viewer.gameIndex]
+	storage := game.InitialStorage
+	for _, move := range game.Moves[:viewer.ply] {
+		storage = move.Apply(storage)
+	}
+
+	return storage
+}
+
+// Jump ...
+//
+// It goes to the position after the specified ply of the opened game.
+func (viewer *Viewer) Jump(ply int) error {
+	moveCount := len(viewer.games[viewer.gameIndex].Moves)
+	if ply < 0 || ply > moveCount {
+		return fmt.Errorf("incorrect ply: %d (allowed: 0-%d)", ply, moveCount)
+	}
+
+	viewer.ply = ply
+	return nil
+}
+
+// OpenGame ...
+//
+// It opens the game with the specified number starting from 1
+// in the initial position.
+func (viewer *Viewer) OpenGame(number int) error {
+	if number < 1 || number > len(viewer.games) {
+		return fmt.Errorf(
+			"incorrect game number: %d (allowed: 1-%d)",
+			number,
+			len(viewer.games),
+		)
+	}
+
+	viewer.gameIndex = number - 1
+	viewer.ply = 0
+	return nil
+}
+
+// Run ...
+//
+// It returns nil on the user request or on the end of the input.
+// Errors of commands are displayed and a command is requested again.
+func (viewer *Viewer) Run() error {
+	if len(viewer.games) == 0 {
+		return errors.New("no games")
+	}
+
+	for {
+		if err := viewer.writeState(); err != nil {
+			return err // don't wrap
+		}
+
+		text, err := viewer.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || text == "") {
+			if err == io.EOF {
+				return nil
+			}
+
+			return fmt.Errorf("unable to read the command: %s", err)
+		}
+
+		err = viewer.executeCommand(strings.Fields(text))
+		switch err {
+		case nil:
+		case errQuit:
+			return nil
+		default:
+			fmt.Fprintf(viewer.writer, "error: %s\n", err) // nolint: errcheck
+		}
+	}
+}
+
+func (viewer *Viewer) executeCommand(fields []string) error {
+	var name string
+	var arguments []string
+	if len(fields) != 0 {
+		name, arguments = fields[0], fields[1:]
+	}
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+
+	switch name {
+	case "help":
+		viewer.writeHelp()
+	case "quit":
+		return errQuit
+	case "next":
+		return viewer.Jump(viewer.ply + 1)
+	case "back":
+		return viewer.Jump(viewer.ply - 1)
+	case "first":
+		return viewer.Jump(0)
+	case "last":
+		return viewer.Jump(len(viewer.games[viewer.gameIndex].Moves))
+	case "jump", "game":
+		if len(arguments) == 0 {
+			return fmt.Errorf("command %s: no number", name)
+		}
+
+		number, err := strconv.Atoi(arguments[0])
+		if err != nil {
+			return fmt.Errorf("command %s: incorrect number: %s", name, err)
+		}
+
+		if name == "jump" {
+			return viewer.Jump(number)
+		}
+
+		return viewer.OpenGame(number)
+	case "games":
+		for index, game := range viewer.games {
+			text := encodeGameTitle(game)
+			fmt.Fprintf(viewer.writer, "%d. %s\n", index+1, text) // nolint: errcheck
+		}
+	default:
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	return nil
+}
+
+func (viewer *Viewer) writeState() error {
+	game := viewer.games[viewer.gameIndex]
+	fmt.Fprintf( // nolint: errcheck
+		viewer.writer,
+		"game %d/%d: %s\n",
+		viewer.Game(),
+		len(viewer.games),
+		encodeGameTitle(game),
+	)
+
+	storage := viewer.Storage()
+	text := viewer.storageEncoder.EncodePieceStorage(storage)
+	fmt.Fprintln(viewer.writer, text) // nolint: errcheck
+
+	lastMove := "none"
+	if viewer.ply != 0 {
+		var err error
+		if lastMove, err = viewer.encodeLastMove(); err != nil {
+			return err // don't wrap
+		}
+	}
+
+	fmt.Fprintf( // nolint: errcheck
+		viewer.writer,
+		"ply %d/%d, last move: %s\nviewer> ",
+		viewer.ply,
+		len(game.Moves),
+		lastMove,
+	)
+
+	return nil
+}
+
+func (viewer *Viewer) encodeLastMove() (string, error) {
+	game := viewer.games[viewer.gameIndex]
+	storage := game.InitialStorage
+	for _, move := range game.Moves[:viewer.ply-1] {
+		storage = move.Apply(storage)
+	}
+
+	move := game.Moves[viewer.ply-1]
+	text, err := san.EncodeMove(storage, move)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the move: %s", err)
+	}
+
+	number := (viewer.ply + 1) / 2
+	if viewer.ply%2 == 0 {
+		return fmt.Sprintf("%d... %s", number, text), nil
+	}
+
+	return fmt.Sprintf("%d. %s", number, text), nil
+}
+
+func (viewer *Viewer) writeHelp() {
+	var names []string
+	for name := range commandDescriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(viewer.writer, "commands:") // nolint: errcheck
+	for _, name := range names {
+		description := commandDescriptions[name]
+		text := fmt.Sprintf("  %-5s - %s", name, description)
+		fmt.Fprintln(viewer.writer, text) // nolint: errcheck
+	}
+}
+
+func encodeGameTitle(game pgn.Game) string {
+	white, black := game.Header.White, game.Header.Black
+	if white == "" {
+		white = "?"
+	}
+	if black == "" {
+		black = "?"
+	}
+
+	title := fmt.Sprintf("%s - %s (%s)", white, black, game.Result)
+	if game.Header.Event != "" {
+		title = game.Header.Event + ": " + title
+	}
+
+	return title
+}
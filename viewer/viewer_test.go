@@ -0,0 +1,192 @@
+package viewer
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/thewizardplusplus/go-chess-cli/encoding/ascii"
+	"github.com/thewizardplusplus/go-chess-cli/encoding/pgn"
+	models "github.com/thewizardplusplus/go-chess-models"
+	"github.com/thewizardplusplus/go-chess-models/encoding/uci"
+)
+
+const testGames = `[Event "First"]
+[White "Human"]
+[Black "Computer"]
+[FEN "k4/2K2/5/5/1Q3 w - - 0 1"]
+
+1. Kb3 Kb5 2. Kc3 *
+
+[Event "Second"]
+[FEN "k4/2K2/5/5/1Q3 w - - 0 1"]
+
+1. Qb5# 1-0`
+
+func newTestViewer(test *testing.T, input string) (*Viewer, *bytes.Buffer) {
+	games, err := pgn.DecodeGames(testGames)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	storageEncoder := ascii.NewPieceStorageEncoder(
+		uci.EncodePiece,
+		".",
+		ascii.Margins{},
+		ascii.WithoutColor,
+		models.Black,
+		1,
+	)
+	viewer := NewViewer(
+		bufio.NewReader(strings.NewReader(input)),
+		&output,
+		storageEncoder,
+		games,
+	)
+	return viewer, &output
+}
+
+func TestViewerJump(test *testing.T) {
+	type args struct {
+		ply int
+	}
+	type data struct {
+		args    args
+		wantPly int
+		wantFEN string
+		wantErr bool
+	}
+
+	for _, data := range []data{
+		{
+			args:    args{0},
+			wantPly: 0,
+			wantFEN: "k4/2K2/5/5/1Q3",
+			wantErr: false,
+		},
+		{
+			args:    args{2},
+			wantPly: 2,
+			wantFEN: "1k3/5/1K3/5/1Q3",
+			wantErr: false,
+		},
+		{
+			args:    args{4},
+			wantPly: 0,
+			wantFEN: "k4/2K2/5/5/1Q3",
+			wantErr: true,
+		},
+		{
+			args:    args{-1},
+			wantPly: 0,
+			wantFEN: "k4/2K2/5/5/1Q3",
+			wantErr: true,
+		},
+	} {
+		viewer, _ := newTestViewer(test, "")
+		gotErr := viewer.Jump(data.args.ply)
+
+		if viewer.Ply() != data.wantPly {
+			test.Fail()
+		}
+		if uci.EncodePieceStorage(viewer.Storage()) != data.wantFEN {
+			test.Fail()
+		}
+		if hasErr := gotErr != nil; hasErr != data.wantErr {
+			test.Fail()
+		}
+	}
+}
+
+func TestViewerRun(test *testing.T) {
+	type args struct {
+		input string
+	}
+	type data struct {
+		args       args
+		wantOutput []string
+		wantGame   int
+		wantPly    int
+	}
+
+	for _, data := range []data{
+		{
+			args: args{"n\n\nnext\nb\n"},
+			wantOutput: []string{
+				"game 1/2: First: Human - Computer (*)\n",
+				"ply 0/3, last move: none\nviewer> ",
+				"ply 1/3, last move: 1. Kb3\n",
+				"ply 2/3, last move: 1... Kb5\n",
+				"ply 3/3, last move: 2. Kc3+\n",
+			},
+			wantGame: 1,
+			wantPly:  2,
+		},
+		{
+			args: args{"last\nfirst\njump 2"},
+			wantOutput: []string{
+				"ply 3/3, last move: 2. Kc3+\n",
+				"ply 2/3, last move: 1... Kb5\n",
+			},
+			wantGame: 1,
+			wantPly:  2,
+		},
+		{
+			args: args{"games\ngame 2\nlast\nquit\nnext\n"},
+			wantOutput: []string{
+				"1. First: Human - Computer (*)\n2. Second: ? - ? (1-0)\n",
+				"game 2/2: Second: ? - ? (1-0)\n",
+				"ply 1/1, last move: 1. Qb5#\n",
+			},
+			wantGame: 2,
+			wantPly:  1,
+		},
+		{
+			args: args{"back\njump\njump x\ngame 3\nunknown\nhelp\n"},
+			wantOutput: []string{
+				"error: incorrect ply: -1 (allowed: 0-3)\n",
+				"error: command jump: no number\n",
+				"error: command jump: incorrect number: ",
+				"error: incorrect game number: 3 (allowed: 1-2)\n",
+				"error: unknown command: unknown\n",
+				"commands:\n",
+			},
+			wantGame: 1,
+			wantPly:  0,
+		},
+	} {
+		viewer, output := newTestViewer(test, data.args.input)
+		gotErr := viewer.Run()
+
+		for _, line := range data.wantOutput {
+			if !strings.Contains(output.String(), line) {
+				test.Fail()
+			}
+		}
+		if viewer.Game() != data.wantGame {
+			test.Fail()
+		}
+		if viewer.Ply() != data.wantPly {
+			test.Fail()
+		}
+		if gotErr != nil {
+			test.Fail()
+		}
+	}
+}
+
+func TestViewerRun_withoutGames(test *testing.T) {
+	viewer := NewViewer(
+		bufio.NewReader(strings.NewReader("")),
+		&bytes.Buffer{},
+		ascii.PieceStorageEncoder{},
+		nil,
+	)
+	gotErr := viewer.Run()
+
+	if gotErr == nil {
+		test.Fail()
+	}
+}